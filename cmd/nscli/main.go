@@ -12,6 +12,8 @@ import (
 	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
 	bankcmd "github.com/cosmos/cosmos-sdk/x/bank/client/cli"
 	app "github.com/jerryma0912/Cosmos-sdk-tutorial"
+	nsdns "github.com/jerryma0912/Cosmos-sdk-tutorial/x/nameservice/client/dns"
+	nsoffchain "github.com/jerryma0912/Cosmos-sdk-tutorial/x/nameservice/client/offchain"
 	"github.com/spf13/cobra" //提供CLI交互接口
 	"github.com/spf13/viper"
 	"github.com/tendermint/go-amino"
@@ -53,7 +55,9 @@ func main() {
 		queryCmd(cdc),
 		txCmd(cdc),
 		client.LineBreak,
-		lcd.ServeCommand(cdc, registerRoutes),
+		nsoffchain.RegisterRESTServerFlags(nsdns.RegisterRESTServerFlags(lcd.ServeCommand(cdc, registerRoutes))),
+		nsdns.Command(storeNS, cdc),
+		nsoffchain.Command(storeNS, cdc),
 		client.LineBreak,
 		keys.Commands(),
 		client.LineBreak,
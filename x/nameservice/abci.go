@@ -0,0 +1,57 @@
+package nameservice
+
+import (
+	"github.com/jerryma0912/Cosmos-sdk-tutorial/x/nameservice/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// EndBlocker 在每个区块结束时结算揭示期已结束的拍卖，清理过期的域名承诺，
+// 并释放宽限期已结束的域名，使其回到无主的 NewWhois() 状态
+// EndBlocker settles closed auctions, prunes stale commitments and releases
+// every name whose grace period ended before the current block
+func EndBlocker(ctx sdk.Context, keeper Keeper) sdk.Tags {
+	tags := settleAuctions(ctx, keeper)
+	keeper.PruneCommitments(ctx, ctx.BlockHeight()-keeper.MaxCommitmentAge(ctx))
+	return tags.AppendTags(releaseExpiredNames(ctx, keeper))
+}
+
+func settleAuctions(ctx sdk.Context, keeper Keeper) sdk.Tags {
+	iterator := keeper.GetClosedAuctionsIterator(ctx, ctx.BlockHeight())
+	var closed []string
+	for ; iterator.Valid(); iterator.Next() {
+		_, name := types.SplitAuctionQueueKey(iterator.Key())
+		closed = append(closed, name)
+	}
+	iterator.Close()
+
+	// 不能在遍历的同时修改存储
+	tags := sdk.EmptyTags()
+	for _, name := range closed {
+		auction, _ := keeper.GetAuction(ctx, name)
+		tags = tags.AppendTags(keeper.SettleAuction(ctx, auction))
+	}
+	return tags
+}
+
+func releaseExpiredNames(ctx sdk.Context, keeper Keeper) sdk.Tags {
+	iterator := keeper.GetExpiredNamesIterator(ctx, ctx.BlockHeight()-keeper.GracePeriod(ctx))
+	var expired []string
+	for ; iterator.Valid(); iterator.Next() {
+		_, name := types.SplitExpiryQueueKey(iterator.Key())
+		expired = append(expired, name)
+	}
+	iterator.Close()
+
+	tags := sdk.EmptyTags()
+	for _, name := range expired {
+		tags = tags.AppendTags(sdk.NewTags(
+			types.TagAction, types.ActionNameReleased,
+			types.TagCategory, types.TxCategory,
+			types.TagName, name,
+			types.TagPreviousOwner, keeper.GetOwner(ctx, name).String(),
+		))
+		keeper.DeleteWhois(ctx, name)
+	}
+	return tags
+}
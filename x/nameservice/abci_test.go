@@ -0,0 +1,49 @@
+package nameservice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestEndBlocker(t *testing.T) {
+	ctx, keeper := CreateTestInput(t)
+	params := DefaultParams()
+	params.RegistrationPeriod = 10
+	params.GracePeriod = 5
+	params.AuctionsEnabled = true
+	params.AuctionCommitPeriod = 2
+	params.AuctionRevealPeriod = 2
+	keeper.SetParams(ctx, params)
+	handler := NewHandler(keeper)
+	price := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 5))
+
+	// alice expires at height 10 and is released once its grace period has ended
+	keeper.SetWhois(ctx, "alice", Whois{Value: "1.2.3.4", Owner: addr1, Price: price, Expires: 10})
+	EndBlocker(ctx.WithBlockHeight(15), keeper)
+	require.True(t, keeper.HasOwner(ctx, "alice"))
+	EndBlocker(ctx.WithBlockHeight(16), keeper)
+	require.False(t, keeper.HasOwner(ctx, "alice"))
+	require.Equal(t, NewWhois(keeper.MinNamePrice(ctx)), keeper.GetWhois(ctx, "alice"))
+
+	// an auction for bob opened at height 1 is settled after its reveal window ends at height 5
+	_, err := keeper.coinKeeper.AddCoins(ctx, addr2, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 10)))
+	require.NoError(t, err)
+	bid := sdk.NewInt64Coin("nametoken", 3)
+	res := handler(ctx, NewMsgCommitBid("bob", GetBidHash("bob", addr2, bid, "salt"), sdk.NewInt64Coin("nametoken", 4), addr2))
+	require.True(t, res.IsOK(), res.Log)
+	res = handler(ctx.WithBlockHeight(4), NewMsgRevealBid("bob", bid, "salt", addr2))
+	require.True(t, res.IsOK(), res.Log)
+
+	EndBlocker(ctx.WithBlockHeight(5), keeper)
+	_, found := keeper.GetAuction(ctx, "bob")
+	require.True(t, found)
+	EndBlocker(ctx.WithBlockHeight(6), keeper)
+	_, found = keeper.GetAuction(ctx, "bob")
+	require.False(t, found)
+	require.Equal(t, addr2, keeper.GetOwner(ctx, "bob"))
+	// the winner pays the minimum price as there was no second bid
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 9)), keeper.coinKeeper.GetCoins(ctx, addr2))
+}
@@ -0,0 +1,56 @@
+package nameservice
+
+// 多链地址记录：每个域名可以为不同的链（以 SLIP-44 币种区分）各设置一个地址，
+// 与 Whois 分开存储，域名被释放时一并删除。
+import (
+	"encoding/binary"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	"github.com/jerryma0912/Cosmos-sdk-tutorial/x/nameservice/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GetAddress - returns the address of a name for a coin type, or an empty string
+func (k Keeper) GetAddress(ctx sdk.Context, name string, coinType uint32) string {
+	store := ctx.KVStore(k.storeKey)
+	return string(store.Get(types.GetAddressKey(name, coinType)))
+}
+
+// SetAddress - sets the address of a name for a coin type, removing it when empty
+func (k Keeper) SetAddress(ctx sdk.Context, name string, coinType uint32, addr string) {
+	store := ctx.KVStore(k.storeKey)
+	if addr == "" {
+		store.Delete(types.GetAddressKey(name, coinType))
+		return
+	}
+	store.Set(types.GetAddressKey(name, coinType), []byte(addr))
+}
+
+// GetAddressesIterator - returns an iterator over the addresses of a name, in which
+// the keys are the big endian coin types and the values are the addresses
+func (k Keeper) GetAddressesIterator(ctx sdk.Context, name string) sdk.Iterator {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.GetAddressesPrefix(name))
+	return sdk.KVStorePrefixIterator(store, nil)
+}
+
+// GetAddresses - returns all addresses of a name, ordered by coin type
+func (k Keeper) GetAddresses(ctx sdk.Context, name string) AddressRecords {
+	addrs := AddressRecords{}
+	iterator := k.GetAddressesIterator(ctx, name)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		addrs = append(addrs, AddressRecord{
+			CoinType: binary.BigEndian.Uint32(iterator.Key()),
+			Address:  string(iterator.Value()),
+		})
+	}
+	return addrs
+}
+
+// DeleteAddresses - removes all addresses of a name
+func (k Keeper) DeleteAddresses(ctx sdk.Context, name string) {
+	for _, addr := range k.GetAddresses(ctx, name) {
+		k.SetAddress(ctx, name, addr.CoinType, "")
+	}
+}
@@ -5,23 +5,135 @@ import (
 )
 
 const (
-	ModuleName = types.ModuleName
-	RouterKey  = types.RouterKey
-	StoreKey   = types.StoreKey
+	ModuleName          = types.ModuleName
+	RouterKey           = types.RouterKey
+	StoreKey            = types.StoreKey
+	DefaultParamspace   = types.DefaultParamspace
+	MaxRecordsPerName   = types.MaxRecordsPerName
+	MaxAliasDepth       = types.MaxAliasDepth
+	MaxAddressesPerName = types.MaxAddressesPerName
+	MaxTextsPerName     = types.MaxTextsPerName
+	MaxSearchScan       = types.MaxSearchScan
+	MaxNamesLimit       = types.MaxNamesLimit
+
+	FuseCannotReclaim        = types.FuseCannotReclaim
+	FuseCannotSetRecords     = types.FuseCannotSetRecords
+	FuseCannotTransfer       = types.FuseCannotTransfer
+	FuseCannotCreateChildren = types.FuseCannotCreateChildren
+
+	RegistrationModeOpen      = types.RegistrationModeOpen
+	RegistrationModeAuction   = types.RegistrationModeAuction
+	RegistrationModeRegistrar = types.RegistrationModeRegistrar
+
+	ProposalTypeCreateTLD = types.ProposalTypeCreateTLD
 )
 
 var (
-	NewMsgBuyName = types.NewMsgBuyName
-	NewMsgSetName = types.NewMsgSetName
-	NewWhois      = types.NewWhois
-	ModuleCdc     = types.ModuleCdc
-	RegisterCodec = types.RegisterCodec
+	NewMsgBuyName              = types.NewMsgBuyName
+	NewMsgSetName              = types.NewMsgSetName
+	NewMsgRenewName            = types.NewMsgRenewName
+	NewMsgCommitBid            = types.NewMsgCommitBid
+	NewMsgRevealBid            = types.NewMsgRevealBid
+	NewMsgTransferName         = types.NewMsgTransferName
+	NewMsgAcceptTransfer       = types.NewMsgAcceptTransfer
+	NewMsgCancelTransfer       = types.NewMsgCancelTransfer
+	NewMsgSetRecord            = types.NewMsgSetRecord
+	NewMsgDeleteRecord         = types.NewMsgDeleteRecord
+	NewRecord                  = types.NewRecord
+	NewMsgSetPrimaryName       = types.NewMsgSetPrimaryName
+	NewMsgCreateSubdomain      = types.NewMsgCreateSubdomain
+	ValidateName               = types.ValidateName
+	NormalizeName              = types.NormalizeName
+	DisplayName                = types.DisplayName
+	NormalizeSearchTerm        = types.NormalizeSearchTerm
+	IsSubdomain                = types.IsSubdomain
+	ParentName                 = types.ParentName
+	IsWildcard                 = types.IsWildcard
+	NewMsgBurnFuses            = types.NewMsgBurnFuses
+	ParseFuses                 = types.ParseFuses
+	NewTLD                     = types.NewTLD
+	NewMsgSetTLD               = types.NewMsgSetTLD
+	NewCreateTLDProposal       = types.NewCreateTLDProposal
+	NewMsgSetAlias             = types.NewMsgSetAlias
+	NewMsgSetAddress           = types.NewMsgSetAddress
+	NewMsgSetText              = types.NewMsgSetText
+	NewMsgSetContentHash       = types.NewMsgSetContentHash
+	NewMsgSetGateway           = types.NewMsgSetGateway
+	NewGateway                 = types.NewGateway
+	ParseContentHash           = types.ParseContentHash
+	NewQueryResContentHash     = types.NewQueryResContentHash
+	ValidateAddress            = types.ValidateAddress
+	NewMsgCommitName           = types.NewMsgCommitName
+	NewAuction                 = types.NewAuction
+	GetBidHash                 = types.GetBidHash
+	GetNameCommitment          = types.GetNameCommitment
+	NewWhois                   = types.NewWhois
+	NewQueryNamesParams        = types.NewQueryNamesParams
+	NewQuerySearchParams       = types.NewQuerySearchParams
+	NewQueryAvailabilityParams = types.NewQueryAvailabilityParams
+	DefaultParams              = types.DefaultParams
+	ParamKeyTable              = types.ParamKeyTable
+	ModuleCdc                  = types.ModuleCdc
+	RegisterCodec              = types.RegisterCodec
 )
 
 type (
-	MsgSetName      = types.MsgSetName
-	MsgBuyName      = types.MsgBuyName
-	QueryResResolve = types.QueryResResolve
-	QueryResNames   = types.QueryResNames
-	Whois           = types.Whois
+	MsgSetName              = types.MsgSetName
+	MsgBuyName              = types.MsgBuyName
+	MsgRenewName            = types.MsgRenewName
+	MsgCommitBid            = types.MsgCommitBid
+	MsgRevealBid            = types.MsgRevealBid
+	MsgCommitName           = types.MsgCommitName
+	QueryResResolve         = types.QueryResResolve
+	QueryResRecords         = types.QueryResRecords
+	QueryResAddresses       = types.QueryResAddresses
+	QueryResTexts           = types.QueryResTexts
+	QueryResContentHash     = types.QueryResContentHash
+	QueryResNames           = types.QueryResNames
+	QueryResNamesPage       = types.QueryResNamesPage
+	QueryNamesParams        = types.QueryNamesParams
+	QuerySearchParams       = types.QuerySearchParams
+	QueryResSearch          = types.QueryResSearch
+	QueryResSearchPage      = types.QueryResSearchPage
+	NameSummary             = types.NameSummary
+	QueryAvailabilityParams = types.QueryAvailabilityParams
+	QueryResAvailability    = types.QueryResAvailability
+	NameAvailability        = types.NameAvailability
+	QueryResAuction         = types.QueryResAuction
+	Whois                   = types.Whois
+	Params                  = types.Params
+	BuyNameResult           = types.BuyNameResult
+	RenewNameResult         = types.RenewNameResult
+	Auction                 = types.Auction
+	Bid                     = types.Bid
+	PendingTransfer         = types.PendingTransfer
+	TransferNameResult      = types.TransferNameResult
+	MsgTransferName         = types.MsgTransferName
+	MsgAcceptTransfer       = types.MsgAcceptTransfer
+	MsgCancelTransfer       = types.MsgCancelTransfer
+	MsgSetRecord            = types.MsgSetRecord
+	MsgDeleteRecord         = types.MsgDeleteRecord
+	Record                  = types.Record
+	Records                 = types.Records
+	MsgSetPrimaryName       = types.MsgSetPrimaryName
+	QueryResReverse         = types.QueryResReverse
+	MsgCreateSubdomain      = types.MsgCreateSubdomain
+	MsgBurnFuses            = types.MsgBurnFuses
+	Fuses                   = types.Fuses
+	TLD                     = types.TLD
+	MsgSetTLD               = types.MsgSetTLD
+	CreateTLDProposal       = types.CreateTLDProposal
+	MsgSetAlias             = types.MsgSetAlias
+	MsgSetAddress           = types.MsgSetAddress
+	AddressRecord           = types.AddressRecord
+	AddressRecords          = types.AddressRecords
+	MsgSetText              = types.MsgSetText
+	TextRecord              = types.TextRecord
+	TextRecords             = types.TextRecords
+	MsgSetContentHash       = types.MsgSetContentHash
+	ContentHash             = types.ContentHash
+	MsgSetGateway           = types.MsgSetGateway
+	Gateway                 = types.Gateway
+	QueryResTLDs            = types.QueryResTLDs
+	Commitment              = types.Commitment
 )
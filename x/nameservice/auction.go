@@ -0,0 +1,133 @@
+package nameservice
+
+// 密封竞价（Vickrey）拍卖：无主域名由出价最高者获得，但只需支付第二高的出价。
+import (
+	"github.com/jerryma0912/Cosmos-sdk-tutorial/x/nameservice/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GetAuction - gets the running auction for a name, if any
+func (k Keeper) GetAuction(ctx sdk.Context, name string) (auction Auction, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetAuctionKey(name))
+	if bz == nil {
+		return auction, false
+	}
+	k.cdc.MustUnmarshalBinaryBare(bz, &auction)
+	return auction, true
+}
+
+// SetAuction - stores an auction and queues it for settlement after its reveal window
+func (k Keeper) SetAuction(ctx sdk.Context, auction Auction) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetAuctionKey(auction.Name), k.cdc.MustMarshalBinaryBare(auction))
+	store.Set(types.GetAuctionQueueKey(auction.RevealEnd, auction.Name), []byte{})
+}
+
+// DeleteAuction - removes an auction together with its queue entry and all of its bids
+func (k Keeper) DeleteAuction(ctx sdk.Context, auction Auction) {
+	store := ctx.KVStore(k.storeKey)
+	for _, bid := range k.GetBids(ctx, auction.Name) {
+		store.Delete(types.GetBidKey(bid.Name, bid.Bidder))
+	}
+	store.Delete(types.GetAuctionQueueKey(auction.RevealEnd, auction.Name))
+	store.Delete(types.GetAuctionKey(auction.Name))
+}
+
+// GetBid - gets the sealed bid of a bidder for a name, if any
+func (k Keeper) GetBid(ctx sdk.Context, name string, bidder sdk.AccAddress) (bid Bid, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetBidKey(name, bidder))
+	if bz == nil {
+		return bid, false
+	}
+	k.cdc.MustUnmarshalBinaryBare(bz, &bid)
+	return bid, true
+}
+
+// SetBid - stores a sealed bid
+func (k Keeper) SetBid(ctx sdk.Context, bid Bid) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetBidKey(bid.Name, bid.Bidder), k.cdc.MustMarshalBinaryBare(bid))
+}
+
+// DeleteBid - removes a sealed bid
+func (k Keeper) DeleteBid(ctx sdk.Context, bid Bid) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetBidKey(bid.Name, bid.Bidder))
+}
+
+// GetBids - returns all bids still held for the auction of a name
+func (k Keeper) GetBids(ctx sdk.Context, name string) (bids []Bid) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.GetBidsKey(name))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var bid Bid
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &bid)
+		bids = append(bids, bid)
+	}
+	return bids
+}
+
+// GetAuctionsIterator - returns an iterator over all running auctions
+func (k Keeper) GetAuctionsIterator(ctx sdk.Context) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.AuctionKeyPrefix)
+}
+
+// GetClosedAuctionsIterator - returns an iterator over the auction queue entries of all
+// auctions whose reveal window ended strictly before the given height
+func (k Keeper) GetClosedAuctionsIterator(ctx sdk.Context, height int64) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return store.Iterator(types.AuctionQueueKeyPrefix, types.GetAuctionQueueHeightKey(height))
+}
+
+// RefundBid - returns the escrowed deposit of a bid to its bidder and removes the bid
+func (k Keeper) RefundBid(ctx sdk.Context, bid Bid) {
+	_, err := k.coinKeeper.AddCoins(ctx, bid.Bidder, sdk.NewCoins(bid.Deposit))
+	if err != nil {
+		panic(err)
+	}
+	k.DeleteBid(ctx, bid)
+}
+
+// SettleAuction - gives the name to the highest bidder at the second-highest price
+// and refunds every other deposit. If the price is not covered by the deposit of the
+// winner the name is not given away and every deposit is refunded.
+func (k Keeper) SettleAuction(ctx sdk.Context, auction Auction) sdk.Tags {
+	tags := sdk.NewTags(
+		types.TagAction, types.ActionAuctionSettled,
+		types.TagCategory, types.TxCategory,
+		types.TagName, auction.Name,
+	)
+	// 拍卖期间域名可能已通过其他方式获得所有者，此时退还所有押金
+	won := !auction.HighestBidder.Empty() && !k.HasOwner(ctx, auction.Name)
+	price := auction.Price()
+	for _, bid := range k.GetBids(ctx, auction.Name) {
+		if won && bid.Bidder.Equals(auction.HighestBidder) && bid.Deposit.Denom == price.Denom && bid.Deposit.IsGTE(price) {
+			registry := k.GetRegistry(ctx, auction.Name)
+			// 押金超出成交价的部分退还给获胜者，成交价被燃烧，TLD 下的域名则付给注册商
+			bid.Deposit = bid.Deposit.Sub(price)
+			if !registry.IsRoot() {
+				if _, err := k.coinKeeper.AddCoins(ctx, registry.Registrar, sdk.NewCoins(price)); err != nil {
+					panic(err)
+				}
+			}
+			whois := k.GetWhois(ctx, auction.Name)
+			whois.Owner = bid.Bidder
+			whois.Price = sdk.NewCoins(price)
+			whois.Expires = ctx.BlockHeight() + k.RegistrationPeriod(ctx)
+			k.SetWhois(ctx, auction.Name, whois)
+			tags = tags.AppendTags(sdk.NewTags(
+				types.TagOwner, bid.Bidder.String(),
+				types.TagPrice, whois.Price.String(),
+			))
+		}
+		k.RefundBid(ctx, bid)
+	}
+	k.DeleteAuction(ctx, auction)
+	return tags
+}
@@ -0,0 +1,134 @@
+package nameservice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var addr3 = sdk.AccAddress([]byte("addr3_______________"))
+
+// auctionTestInput returns a context at height 1 whose auctions commit until height 3
+// and reveal until height 5, and a handler for it
+func auctionTestInput(t *testing.T) (sdk.Context, Keeper, sdk.Handler) {
+	ctx, keeper := CreateTestInput(t)
+	params := DefaultParams()
+	params.AuctionsEnabled = true
+	params.AuctionCommitPeriod = 2
+	params.AuctionRevealPeriod = 2
+	keeper.SetParams(ctx, params)
+	return ctx, keeper, NewHandler(keeper)
+}
+
+func fund(t *testing.T, ctx sdk.Context, keeper Keeper, addr sdk.AccAddress, coins sdk.Coins) {
+	_, err := keeper.coinKeeper.AddCoins(ctx, addr, coins)
+	require.NoError(t, err)
+}
+
+func TestAuctionKeepsMinPrice(t *testing.T) {
+	ctx, keeper, handler := auctionTestInput(t)
+	tld := NewTLD("eth", addr1, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 2)), []string{"nametoken"}, RegistrationModeAuction)
+	keeper.SetTLD(ctx, tld)
+	fund(t, ctx, keeper, addr2, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 10)))
+
+	bid := sdk.NewInt64Coin("nametoken", 3)
+	res := handler(ctx, NewMsgCommitBid("x.eth", GetBidHash("x.eth", addr2, bid, "salt"), sdk.NewInt64Coin("nametoken", 5), addr2))
+	require.True(t, res.IsOK(), res.Log)
+
+	// the registrar changes the price and denomination of the TLD while the auction runs
+	tld.MinPrice = sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
+	tld.AllowedDenoms = []string{"stake"}
+	keeper.SetTLD(ctx, tld)
+
+	res = handler(ctx.WithBlockHeight(4), NewMsgRevealBid("x.eth", bid, "salt", addr2))
+	require.True(t, res.IsOK(), res.Log)
+	EndBlocker(ctx.WithBlockHeight(6), keeper)
+
+	// the auction is settled against the min price it was opened with
+	require.Equal(t, addr2, keeper.GetOwner(ctx, "x.eth"))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 8)), keeper.coinKeeper.GetCoins(ctx, addr2))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 2)), keeper.coinKeeper.GetCoins(ctx, addr1))
+}
+
+func TestSettleAuctionPriceAboveDeposit(t *testing.T) {
+	ctx, keeper, _ := auctionTestInput(t)
+	deposit := sdk.NewInt64Coin("nametoken", 1)
+	auction := NewAuction("carol", 1, 2, 2, sdk.NewInt64Coin("nametoken", 5))
+	auction.HighestBidder, auction.HighestBid = addr3, deposit
+	keeper.SetAuction(ctx, auction)
+	keeper.SetBid(ctx, Bid{Name: "carol", Bidder: addr3, Deposit: deposit, Revealed: true})
+
+	// the name is not given away and the deposit is refunded instead of panicking
+	require.NotPanics(t, func() { keeper.SettleAuction(ctx.WithBlockHeight(6), auction) })
+	require.False(t, keeper.HasOwner(ctx, "carol"))
+	require.Equal(t, sdk.NewCoins(deposit), keeper.coinKeeper.GetCoins(ctx, addr3))
+}
+
+func TestAuctionSettlement(t *testing.T) {
+	ctx, keeper, handler := auctionTestInput(t)
+	addr4 := sdk.AccAddress([]byte("addr4_______________"))
+	balance := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 20))
+	bids := []struct {
+		bidder  sdk.AccAddress
+		bid     int64
+		deposit int64
+	}{
+		{addr1, 5, 6},  // second-highest bid, sets the price
+		{addr2, 7, 10}, // highest bid
+		{addr3, 4, 4},
+		{addr4, 9, 9}, // never revealed
+	}
+	for _, b := range bids {
+		fund(t, ctx, keeper, b.bidder, balance)
+		hash := GetBidHash("alice", b.bidder, sdk.NewInt64Coin("nametoken", b.bid), "salt")
+		res := handler(ctx, NewMsgCommitBid("alice", hash, sdk.NewInt64Coin("nametoken", b.deposit), b.bidder))
+		require.True(t, res.IsOK(), res.Log)
+	}
+
+	// bids cannot be revealed during the commit window
+	res := handler(ctx.WithBlockHeight(3), NewMsgRevealBid("alice", sdk.NewInt64Coin("nametoken", 5), "salt", addr1))
+	require.False(t, res.IsOK())
+	revealCtx := ctx.WithBlockHeight(4)
+	for _, b := range bids[:3] {
+		res := handler(revealCtx, NewMsgRevealBid("alice", sdk.NewInt64Coin("nametoken", b.bid), "salt", b.bidder))
+		require.True(t, res.IsOK(), res.Log)
+	}
+
+	EndBlocker(ctx.WithBlockHeight(6), keeper)
+	_, found := keeper.GetAuction(ctx, "alice")
+	require.False(t, found)
+	require.Equal(t, addr2, keeper.GetOwner(ctx, "alice"))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 5)), keeper.GetPrice(ctx, "alice"))
+	require.Equal(t, int64(6)+keeper.RegistrationPeriod(ctx), keeper.GetExpires(ctx, "alice"))
+
+	// the winner pays the second-highest bid, every other deposit is refunded,
+	// including the deposit of the bid that was never revealed
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 15)), keeper.coinKeeper.GetCoins(ctx, addr2))
+	for _, addr := range []sdk.AccAddress{addr1, addr3, addr4} {
+		require.Equal(t, balance, keeper.coinKeeper.GetCoins(ctx, addr), addr.String())
+	}
+	require.Empty(t, keeper.GetBids(ctx, "alice"))
+}
+
+func TestAuctionTie(t *testing.T) {
+	ctx, keeper, handler := auctionTestInput(t)
+	balance := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 10))
+	bid := sdk.NewInt64Coin("nametoken", 4)
+	for _, addr := range []sdk.AccAddress{addr1, addr2} {
+		fund(t, ctx, keeper, addr, balance)
+		res := handler(ctx, NewMsgCommitBid("alice", GetBidHash("alice", addr, bid, "salt"), bid, addr))
+		require.True(t, res.IsOK(), res.Log)
+	}
+
+	// of two equal bids the one revealed first wins, at the price of the tied bid
+	for _, addr := range []sdk.AccAddress{addr2, addr1} {
+		res := handler(ctx.WithBlockHeight(4), NewMsgRevealBid("alice", bid, "salt", addr))
+		require.True(t, res.IsOK(), res.Log)
+	}
+	EndBlocker(ctx.WithBlockHeight(6), keeper)
+	require.Equal(t, addr2, keeper.GetOwner(ctx, "alice"))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 6)), keeper.coinKeeper.GetCoins(ctx, addr2))
+	require.Equal(t, balance, keeper.coinKeeper.GetCoins(ctx, addr1))
+}
@@ -3,12 +3,25 @@ package cli
 // 为你模块的每个Query（resolve和whois）定义cobra.Command
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/jerryma0912/Cosmos-sdk-tutorial/x/nameservice/client/offchain"
 	"github.com/jerryma0912/Cosmos-sdk-tutorial/x/nameservice/types"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	flagPage     = "page"
+	flagLimit    = "limit"
+	flagStart    = "start"
+	flagContains = "contains"
+	flagParent   = "parent"
+	flagChildren = "children"
 )
 
 func GetQueryCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
@@ -23,6 +36,19 @@ func GetQueryCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 		GetCmdResolveName(storeKey, cdc),
 		GetCmdWhois(storeKey, cdc),
 		GetCmdNames(storeKey, cdc),
+		GetCmdAuction(storeKey, cdc),
+		GetCmdParams(storeKey, cdc),
+		GetCmdNamesOf(storeKey, cdc),
+		GetCmdSearch(storeKey, cdc),
+		GetCmdAvailable(storeKey, cdc),
+		GetCmdTransfer(storeKey, cdc),
+		GetCmdRecords(storeKey, cdc),
+		GetCmdReverse(storeKey, cdc),
+		GetCmdTLD(storeKey, cdc),
+		GetCmdTLDs(storeKey, cdc),
+		GetCmdAddress(storeKey, cdc),
+		GetCmdText(storeKey, cdc),
+		GetCmdContentHash(storeKey, cdc),
 	)...)
 	return nameserviceQueryCmd
 }
@@ -37,7 +63,10 @@ func GetCmdResolveName(queryRoute string, cdc *codec.Codec) *cobra.Command {
 			// CLIContext:
 			// 它包含有关CLI交互所需的用户输入和应用程序配置的数据。
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
-			name := args[0]
+			name, err := types.NormalizeName(args[0])
+			if err != nil {
+				return err
+			}
 
 			// cliCtx.QueryWithData()`函数所需的`path`直接从你的查询路径中映射。
 			//- 路径的第一部分用于区分 SDK 应用程序可能的querier类型：`custom`用于`Querier`
@@ -53,6 +82,14 @@ func GetCmdResolveName(queryRoute string, cdc *codec.Codec) *cobra.Command {
 
 			var out types.QueryResResolve
 			cdc.MustUnmarshalJSON(res, &out)
+			// 域名由链下网关解析时，从网关获取应答并用链上记录的签名者验证
+			if out.Gateway != nil {
+				answer, err := offchain.FetchAnswer(offchain.DefaultClient, *out.Gateway, name, cdc, time.Now())
+				if err != nil {
+					return fmt.Errorf("could not resolve %s through gateway %s: %s", name, out.Gateway.URL, err)
+				}
+				return cliCtx.PrintOutput(answer)
+			}
 			return cliCtx.PrintOutput(out)
 		},
 	}
@@ -60,13 +97,16 @@ func GetCmdResolveName(queryRoute string, cdc *codec.Codec) *cobra.Command {
 
 // GetCmdWhois queries information about a domain
 func GetCmdWhois(queryRoute string, cdc *codec.Codec) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "whois [name]",
 		Short: "Query whois info of name",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
-			name := args[0]
+			name, err := types.NormalizeName(args[0])
+			if err != nil {
+				return err
+			}
 
 			// cliCtx.QueryWithData()函数所需的path直接从你的查询路径中映射。
 			// 路径的第一部分用于区分 SDK 应用程序可能的querier类型：custom用于Querier
@@ -75,6 +115,18 @@ func GetCmdWhois(queryRoute string, cdc *codec.Codec) *cobra.Command {
 			// 在这个例子中，第四部分是查询。这是因为查询参数是一个简单的字符串。
 			// 要启用更复杂的查询输入，你需要使用.QueryWithData()函数的第二个参数来传入data。
 			// 有关此示例，请参阅 Staking 模块中的 queriers。
+			if viper.GetBool(flagChildren) {
+				res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/whois/%s/children", queryRoute, name), nil)
+				if err != nil {
+					fmt.Printf("could not query subdomains - %s \n", name)
+					return nil
+				}
+
+				var out types.QueryResNames
+				cdc.MustUnmarshalJSON(res, &out)
+				return cliCtx.PrintOutput(out)
+			}
+
 			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/whois/%s", queryRoute, name), nil)
 			if err != nil {
 				fmt.Printf("could not resolve whois - %s \n", name)
@@ -86,26 +138,408 @@ func GetCmdWhois(queryRoute string, cdc *codec.Codec) *cobra.Command {
 			return cliCtx.PrintOutput(out)
 		},
 	}
+	cmd.Flags().Bool(flagChildren, false, "List the direct subdomains of the name instead")
+	return cmd
 }
 
-// GetCmdNames queries a list of all names
+// GetCmdNames queries one page of the list of all names
 func GetCmdNames(queryRoute string, cdc *codec.Codec) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "names",
 		Short: "names",
+		Long: strings.TrimSpace(`Query one page of all registered names.
+
+Pages can either be numbered with --page/--limit or continued from the
+"Next Key" printed by the previous page with --start:
+
+$ nscli query nameservice names --limit 50
+$ nscli query nameservice names --limit 50 --start jack
+
+With --parent only the direct subdomains of a name are listed:
+
+$ nscli query nameservice names --parent acme
+`),
 		// Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 
-			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/names", queryRoute), nil)
+			params := types.NewQueryNamesParams(viper.GetInt(flagPage), viper.GetInt(flagLimit), viper.GetString(flagStart), viper.GetString(flagParent))
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/names", queryRoute), bz)
 			if err != nil {
 				fmt.Printf("could not get query names\n")
 				return nil
 			}
 
+			var out types.QueryResNamesPage
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+	cmd.Flags().Int(flagPage, 1, "Query a specific page of paginated results")
+	cmd.Flags().Int(flagLimit, types.DefaultNamesLimit, "Number of names returned per page")
+	cmd.Flags().String(flagStart, "", "Name to start the page from, as returned by the previous page")
+	cmd.Flags().String(flagParent, "", "List only the direct subdomains of this name")
+	return cmd
+}
+
+// GetCmdAuction queries the status of the auction for a name
+func GetCmdAuction(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "auction [name]",
+		Short: "Query the auction of an unowned name",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			name, err := types.NormalizeName(args[0])
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/auction/%s", queryRoute, name), nil)
+			if err != nil {
+				fmt.Printf("could not query auction - %s \n", name)
+				return nil
+			}
+
+			var out types.QueryResAuction
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdParams queries the current nameservice parameters
+func GetCmdParams(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "params",
+		Short: "Query the current nameservice parameters",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/params", queryRoute), nil)
+			if err != nil {
+				fmt.Printf("could not query params\n")
+				return nil
+			}
+
+			var out types.Params
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdNamesOf queries the names owned by an address
+func GetCmdNamesOf(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "names-of [address]",
+		Short: "Query the names owned by an address",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			address := args[0]
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/owner/%s", queryRoute, address), nil)
+			if err != nil {
+				fmt.Printf("could not query names of - %s \n", address)
+				return nil
+			}
+
 			var out types.QueryResNames
 			cdc.MustUnmarshalJSON(res, &out)
 			return cliCtx.PrintOutput(out)
 		},
 	}
 }
+
+// GetCmdSearch searches names by prefix and substring
+func GetCmdSearch(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "search [prefix]",
+		Short: "Search names starting with a prefix, optionally containing a substring",
+		Long: `Search names starting with a prefix, optionally containing a substring. The
+prefix and substring are mapped like names (case folding, UTS #46 mapping and
+punycode) and matched against the canonical ASCII form of names, so a non-ASCII
+fragment only matches whole labels: "bücher" finds xn--bcher-kva, "bü" does not.
+
+A single query scans a bounded number of names. When it stops early, the output ends
+with the Next Key to pass to --start to continue the search.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			prefix := ""
+			if len(args) > 0 {
+				prefix = args[0]
+			}
+			params := types.NewQuerySearchParams(prefix, viper.GetString(flagContains), viper.GetString(flagStart), viper.GetInt(flagLimit))
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/search", queryRoute), bz)
+			if err != nil {
+				fmt.Printf("could not search names - %s \n", err)
+				return nil
+			}
+
+			var out types.QueryResSearchPage
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+	cmd.Flags().String(flagContains, "", "Only return names containing this substring")
+	cmd.Flags().Int(flagLimit, types.DefaultNamesLimit, "Maximum number of names returned")
+	cmd.Flags().String(flagStart, "", "Name to continue the search from, as returned by the previous page")
+	return cmd
+}
+
+// GetCmdAvailable checks whether a list of names is still available
+func GetCmdAvailable(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "available [name] [name...]",
+		Short: "Check the availability and price of names and suggest free alternatives",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			bz, err := cdc.MarshalJSON(types.NewQueryAvailabilityParams(args))
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/available", queryRoute), bz)
+			if err != nil {
+				fmt.Printf("could not check availability - %s \n", err)
+				return nil
+			}
+
+			var out types.QueryResAvailability
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdTransfer queries the pending transfer of a name
+func GetCmdTransfer(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "transfer [name]",
+		Short: "Query the pending transfer of a name",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			name, err := types.NormalizeName(args[0])
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/transfer/%s", queryRoute, name), nil)
+			if err != nil {
+				fmt.Printf("could not query pending transfer - %s \n", name)
+				return nil
+			}
+
+			var out types.PendingTransfer
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdRecords queries the typed records of a name
+func GetCmdRecords(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "records [name] [type]",
+		Short: "Query the records of a name, optionally only those of one type",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			name, err := types.NormalizeName(args[0])
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/records/%s", queryRoute, name)
+			if len(args) > 1 {
+				route = fmt.Sprintf("%s/%s", route, args[1])
+			}
+
+			res, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				fmt.Printf("could not query records - %s \n", name)
+				return nil
+			}
+
+			var out types.QueryResRecords
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdAddress queries the addresses of a name on other chains
+func GetCmdAddress(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "addr [name] [coin-type]",
+		Short: "Query the addresses of a name, optionally only the one of a SLIP-44 coin type",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			name, err := types.NormalizeName(args[0])
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/addr/%s", queryRoute, name)
+			if len(args) > 1 {
+				route = fmt.Sprintf("%s/%s", route, args[1])
+			}
+
+			res, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				fmt.Printf("could not query addresses - %s \n", name)
+				return nil
+			}
+
+			var out types.QueryResAddresses
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdText queries the text records of a name
+func GetCmdText(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "text [name] [key]",
+		Short: "Query the text records of a name, optionally only the one with a key",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			name, err := types.NormalizeName(args[0])
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/text/%s", queryRoute, name)
+			if len(args) > 1 {
+				route = fmt.Sprintf("%s/%s", route, args[1])
+			}
+
+			res, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				fmt.Printf("could not query texts - %s \n", name)
+				return nil
+			}
+
+			var out types.QueryResTexts
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdContentHash queries the content hash of a name
+func GetCmdContentHash(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "contenthash [name]",
+		Short: "Query the content hash of a name",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			name, err := types.NormalizeName(args[0])
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/contenthash/%s", queryRoute, name), nil)
+			if err != nil {
+				fmt.Printf("could not query content hash - %s \n", name)
+				return nil
+			}
+
+			var out types.QueryResContentHash
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdReverse queries the primary name of an address
+func GetCmdReverse(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "reverse [address]",
+		Short: "Query the primary name of an address",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			address := args[0]
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/reverse/%s", queryRoute, address), nil)
+			if err != nil {
+				fmt.Printf("could not reverse resolve address - %s \n", address)
+				return nil
+			}
+
+			var out types.QueryResReverse
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdTLD queries the registrar and registration policy of a TLD
+func GetCmdTLD(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "tld [tld]",
+		Short: "Query the registrar and registration policy of a TLD",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			name, err := types.NormalizeName(args[0])
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/tld/%s", queryRoute, name), nil)
+			if err != nil {
+				fmt.Printf("could not query TLD - %s \n", name)
+				return nil
+			}
+
+			var out types.TLD
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdTLDs queries all TLDs
+func GetCmdTLDs(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "tlds",
+		Short: "Query all TLDs",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/tlds", queryRoute), nil)
+			if err != nil {
+				fmt.Printf("could not query TLDs\n")
+				return nil
+			}
+
+			var out types.QueryResTLDs
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
@@ -2,7 +2,14 @@ package cli
 
 // 在tx.go中定义交易生成
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strconv"
+
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/context"
@@ -10,9 +17,18 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/auth/client/utils" //它提供对CLI控制的帐户的访问权限
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	govcli "github.com/cosmos/cosmos-sdk/x/gov/client/cli"
 	"github.com/jerryma0912/Cosmos-sdk-tutorial/x/nameservice/types"
 )
 
+const (
+	flagSalt          = "salt"
+	flagRequireAccept = "require-accept"
+	flagTTL           = "ttl"
+	flagDenoms        = "denoms"
+)
+
 func GetTxCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 	nameserviceTxCmd := &cobra.Command{
 		Use:                        types.ModuleName,
@@ -25,6 +41,24 @@ func GetTxCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 	nameserviceTxCmd.AddCommand(client.PostCommands(
 		GetCmdBuyName(cdc),
 		GetCmdSetName(cdc),
+		GetCmdRenewName(cdc),
+		GetCmdCommitBid(cdc),
+		GetCmdRevealBid(cdc),
+		GetCmdCommitName(cdc),
+		GetCmdTransferName(cdc),
+		GetCmdAcceptTransfer(cdc),
+		GetCmdCancelTransfer(cdc),
+		GetCmdSetRecord(cdc),
+		GetCmdDeleteRecord(cdc),
+		GetCmdSetPrimaryName(cdc),
+		GetCmdCreateSubdomain(cdc),
+		GetCmdBurnFuses(cdc),
+		GetCmdSetTLD(cdc),
+		GetCmdSetAlias(cdc),
+		GetCmdSetAddress(cdc),
+		GetCmdSetText(cdc),
+		GetCmdSetContentHash(cdc),
+		GetCmdSetGateway(cdc),
 	)...)
 
 	return nameserviceTxCmd
@@ -32,10 +66,12 @@ func GetTxCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 
 // GetCmdBuyName is the CLI command for sending a BuyName transaction
 func GetCmdBuyName(cdc *codec.Codec) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "buy-name [name] [amount]",
 		Short: "bid for existing name or claim new name",
-		Args:  cobra.ExactArgs(2),
+		Long: `Bid for an existing name or claim a new name. The purchase must match a
+commitment made earlier with "commit" using the same salt.`,
+		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc).WithAccountDecoder(cdc)
 
@@ -45,12 +81,22 @@ func GetCmdBuyName(cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
+			name, err := types.NormalizeName(args[0])
+			if err != nil {
+				return err
+			}
+
 			coins, err := sdk.ParseCoins(args[1])
 			if err != nil {
 				return err
 			}
 
-			msg := types.NewMsgBuyName(args[0], coins, cliCtx.GetFromAddress())
+			salt, err := cmd.Flags().GetString(flagSalt)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgBuyName(name, coins, cliCtx.GetFromAddress(), salt)
 			err = msg.ValidateBasic()
 			if err != nil {
 				return err
@@ -61,6 +107,8 @@ func GetCmdBuyName(cdc *codec.Codec) *cobra.Command {
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
+	cmd.Flags().String(flagSalt, "", "salt of the commitment made for this purchase, as used or printed by \"commit\"")
+	return cmd
 }
 
 // GetCmdSetName is the CLI command for sending a SetName transaction
@@ -78,8 +126,13 @@ func GetCmdSetName(cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
-			msg := types.NewMsgSetName(args[0], args[1], cliCtx.GetFromAddress())
-			err := msg.ValidateBasic()
+			name, err := types.NormalizeName(args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgSetName(name, args[1], cliCtx.GetFromAddress())
+			err = msg.ValidateBasic()
 			if err != nil {
 				return err
 			}
@@ -91,3 +144,864 @@ func GetCmdSetName(cdc *codec.Codec) *cobra.Command {
 		},
 	}
 }
+
+// GetCmdRenewName is the CLI command for sending a RenewName transaction
+func GetCmdRenewName(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "renew-name [name]",
+		Short: "extend the registration of a name that you own",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc).WithAccountDecoder(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			if err := cliCtx.EnsureAccountExists(); err != nil {
+				return err
+			}
+
+			name, err := types.NormalizeName(args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgRenewName(name, cliCtx.GetFromAddress())
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			cliCtx.PrintResponse = true
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdCommitBid is the CLI command for sending a CommitBid transaction.
+// Only the hash of the bid and salt is broadcast; keep both to reveal the bid later.
+func GetCmdCommitBid(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "commit-bid [name] [bid] [salt] [deposit]",
+		Short: "commit a sealed bid in the auction of an unowned name",
+		Long: `Commit a sealed bid in the auction of an unowned name. Only the hash of
+name, bid and salt is broadcast. The deposit is held in escrow and should be at
+least the bid, or larger to hide the real bid. Keep the bid and salt to reveal it later.`,
+		Args: cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc).WithAccountDecoder(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			if err := cliCtx.EnsureAccountExists(); err != nil {
+				return err
+			}
+
+			name, err := types.NormalizeName(args[0])
+			if err != nil {
+				return err
+			}
+
+			bid, err := sdk.ParseCoin(args[1])
+			if err != nil {
+				return err
+			}
+
+			deposit, err := sdk.ParseCoin(args[3])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgCommitBid(name, types.GetBidHash(name, cliCtx.GetFromAddress(), bid, args[2]), deposit, cliCtx.GetFromAddress())
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			cliCtx.PrintResponse = true
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdRevealBid is the CLI command for sending a RevealBid transaction
+func GetCmdRevealBid(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "reveal-bid [name] [bid] [salt]",
+		Short: "reveal a sealed bid after the commit window of the auction",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc).WithAccountDecoder(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			if err := cliCtx.EnsureAccountExists(); err != nil {
+				return err
+			}
+
+			name, err := types.NormalizeName(args[0])
+			if err != nil {
+				return err
+			}
+
+			bid, err := sdk.ParseCoin(args[1])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgRevealBid(name, bid, args[2], cliCtx.GetFromAddress())
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			cliCtx.PrintResponse = true
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdCommitName is the CLI command for sending a CommitName transaction.
+// Only the hash of name, buyer and salt is broadcast; buy the name later with the same salt.
+func GetCmdCommitName(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "commit [name] [salt]",
+		Short: "commit to buying a name without revealing it",
+		Long: fmt.Sprintf(`Commit to buying a name without revealing it. The salt must be at least %d
+characters long; when it is omitted a random salt is generated and printed. Keep
+the salt: the purchase has to use the same one with "buy-name --salt".`, types.MinSaltLength),
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc).WithAccountDecoder(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			if err := cliCtx.EnsureAccountExists(); err != nil {
+				return err
+			}
+
+			name, err := types.NormalizeName(args[0])
+			if err != nil {
+				return err
+			}
+
+			var salt string
+			if len(args) > 1 {
+				salt = args[1]
+			} else {
+				// 未指定盐值时随机生成，并打印出来以便购买时使用
+				bz := make([]byte, 16)
+				if _, err := rand.Read(bz); err != nil {
+					return err
+				}
+				salt = hex.EncodeToString(bz)
+				fmt.Fprintf(os.Stderr, "Generated salt: %s\n", salt)
+			}
+			if err := types.ValidateSalt(salt); err != nil {
+				return err
+			}
+
+			buyer := cliCtx.GetFromAddress()
+			msg := types.NewMsgCommitName(types.GetNameCommitment(name, buyer, salt), buyer)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			cliCtx.PrintResponse = true
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdTransferName is the CLI command for sending a TransferName transaction
+func GetCmdTransferName(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "transfer-name [name] [recipient]",
+		Short: "transfer a name that you own to another address",
+		Long: `Transfer a name that you own to another address. By default the transfer stays
+pending until the recipient accepts it with "accept-transfer"; pass
+--require-accept=false to move the name immediately.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc).WithAccountDecoder(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			if err := cliCtx.EnsureAccountExists(); err != nil {
+				return err
+			}
+
+			name, err := types.NormalizeName(args[0])
+			if err != nil {
+				return err
+			}
+
+			recipient, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+
+			requireAccept, err := cmd.Flags().GetBool(flagRequireAccept)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgTransferName(name, cliCtx.GetFromAddress(), recipient, requireAccept)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			cliCtx.PrintResponse = true
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().Bool(flagRequireAccept, true, "keep the transfer pending until the recipient accepts it")
+	return cmd
+}
+
+// GetCmdAcceptTransfer is the CLI command for sending an AcceptTransfer transaction
+func GetCmdAcceptTransfer(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "accept-transfer [name]",
+		Short: "accept a pending transfer of a name to you",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc).WithAccountDecoder(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			if err := cliCtx.EnsureAccountExists(); err != nil {
+				return err
+			}
+
+			name, err := types.NormalizeName(args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgAcceptTransfer(name, cliCtx.GetFromAddress())
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			cliCtx.PrintResponse = true
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdCancelTransfer is the CLI command for sending a CancelTransfer transaction
+func GetCmdCancelTransfer(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "cancel-transfer [name]",
+		Short: "cancel a pending transfer of a name that you own",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc).WithAccountDecoder(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			if err := cliCtx.EnsureAccountExists(); err != nil {
+				return err
+			}
+
+			name, err := types.NormalizeName(args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgCancelTransfer(name, cliCtx.GetFromAddress())
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			cliCtx.PrintResponse = true
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdSetRecord is the CLI command for sending a SetRecord transaction
+func GetCmdSetRecord(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-record [name] [type] [value]",
+		Short: "add or update a typed record (A, AAAA, CNAME, MX, TXT, SRV) of a name that you own",
+		Long: `Add or update a typed record of a name that you own. Values use the zone file
+presentation format, e.g.:
+
+$ nscli tx nameservice set-record jack.id A 192.0.2.1 --from jack
+$ nscli tx nameservice set-record jack.id MX "10 mail.example.com." --from jack
+$ nscli tx nameservice set-record jack.id SRV "0 5 5060 sip.example.com." --from jack`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc).WithAccountDecoder(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			if err := cliCtx.EnsureAccountExists(); err != nil {
+				return err
+			}
+
+			name, err := types.NormalizeName(args[0])
+			if err != nil {
+				return err
+			}
+
+			ttl, err := cmd.Flags().GetUint32(flagTTL)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgSetRecord(name, types.NewRecord(args[1], args[2], ttl), cliCtx.GetFromAddress())
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			cliCtx.PrintResponse = true
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().Uint32(flagTTL, types.DefaultRecordTTL, "time to live of the record in seconds")
+	return cmd
+}
+
+// GetCmdDeleteRecord is the CLI command for sending a DeleteRecord transaction
+func GetCmdDeleteRecord(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete-record [name] [type] [value]",
+		Short: "delete a record of a name that you own, or all records of a type if no value is given",
+		Args:  cobra.RangeArgs(2, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc).WithAccountDecoder(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			if err := cliCtx.EnsureAccountExists(); err != nil {
+				return err
+			}
+
+			name, err := types.NormalizeName(args[0])
+			if err != nil {
+				return err
+			}
+
+			value := ""
+			if len(args) > 2 {
+				value = args[2]
+			}
+
+			msg := types.NewMsgDeleteRecord(name, args[1], value, cliCtx.GetFromAddress())
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			cliCtx.PrintResponse = true
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdSetPrimaryName is the CLI command for sending a SetPrimaryName transaction
+func GetCmdSetPrimaryName(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set-primary-name [name]",
+		Short: "set the name your address reverse resolves to, or clear it if no name is given",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc).WithAccountDecoder(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			if err := cliCtx.EnsureAccountExists(); err != nil {
+				return err
+			}
+
+			name := ""
+			if len(args) > 0 {
+				normalized, err := types.NormalizeName(args[0])
+				if err != nil {
+					return err
+				}
+				name = normalized
+			}
+
+			msg := types.NewMsgSetPrimaryName(name, cliCtx.GetFromAddress())
+			err := msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			cliCtx.PrintResponse = true
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdCreateSubdomain is the CLI command for sending a CreateSubdomain transaction
+func GetCmdCreateSubdomain(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "create-subdomain [name] [owner]",
+		Short: "create a subdomain of a name you own, for yourself or for the given owner",
+		Long: `Create a subdomain such as api.acme of a name you own. The subdomain is owned
+by the given address, or by you if no owner is given, and expires together with its
+parent. Creating an existing subdomain again assigns it to the new owner.`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc).WithAccountDecoder(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			if err := cliCtx.EnsureAccountExists(); err != nil {
+				return err
+			}
+
+			name, err := types.NormalizeName(args[0])
+			if err != nil {
+				return err
+			}
+
+			owner := cliCtx.GetFromAddress()
+			if len(args) > 1 {
+				addr, err := sdk.AccAddressFromBech32(args[1])
+				if err != nil {
+					return err
+				}
+				owner = addr
+			}
+
+			msg := types.NewMsgCreateSubdomain(name, owner, cliCtx.GetFromAddress())
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			cliCtx.PrintResponse = true
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdBurnFuses is the CLI command for sending a BurnFuses transaction
+func GetCmdBurnFuses(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "burn-fuses [name] [fuses...]",
+		Short: "irreversibly burn permission fuses of a subdomain",
+		Long: `Burn permission fuses of a subdomain of a name you own. Burned fuses can
+never be cleared again while the subdomain is registered. Once cannot-reclaim is
+burned the parent owner gives up control of the subdomain, and only the owner of the
+subdomain can burn its remaining fuses. The fuses are:
+
+  cannot-reclaim          the parent owner can no longer reassign the subdomain
+  cannot-set-records      the value and records of the subdomain are locked
+  cannot-transfer         the subdomain can no longer be transferred
+  cannot-create-children  no new subdomains can be created below the subdomain
+
+$ nscli tx nameservice burn-fuses api.acme cannot-reclaim cannot-transfer --from jack
+`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc).WithAccountDecoder(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			if err := cliCtx.EnsureAccountExists(); err != nil {
+				return err
+			}
+
+			name, err := types.NormalizeName(args[0])
+			if err != nil {
+				return err
+			}
+
+			fuses, err := types.ParseFuses(args[1:])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgBurnFuses(name, fuses, cliCtx.GetFromAddress())
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			cliCtx.PrintResponse = true
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdSetTLD is the CLI command for sending a SetTLD transaction
+func GetCmdSetTLD(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-tld [tld] [registrar] [min-price] [mode]",
+		Short: "update the registrar and registration policy of an existing TLD",
+		Long: `Update the policy of an existing TLD such as dev. Only its registrar can update
+a TLD; new TLDs are created through a governance proposal (see
+"nscli tx gov submit-proposal create-tld"). Names directly below the TLD (e.g. alice.dev)
+are registered under its policy and payments for them go to the registrar. The mode is
+one of:
+
+  open       unowned names are bought with buy-name
+  auction    unowned names can only be won in a sealed-bid auction
+  registrar  only the registrar can register names
+
+$ nscli tx nameservice set-tld dev cosmos1... 10nametoken open --from registrar
+`,
+		Args: cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc).WithAccountDecoder(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			if err := cliCtx.EnsureAccountExists(); err != nil {
+				return err
+			}
+
+			registrar, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+
+			minPrice, err := sdk.ParseCoins(args[2])
+			if err != nil {
+				return err
+			}
+
+			// 未指定允许的币种时使用最低价格的币种
+			denoms, err := cmd.Flags().GetStringSlice(flagDenoms)
+			if err != nil {
+				return err
+			}
+			if len(denoms) == 0 {
+				for _, coin := range minPrice {
+					denoms = append(denoms, coin.Denom)
+				}
+			}
+
+			name, err := types.NormalizeName(args[0])
+			if err != nil {
+				return err
+			}
+
+			tld := types.NewTLD(name, registrar, minPrice, denoms, args[3])
+			msg := types.NewMsgSetTLD(tld, cliCtx.GetFromAddress())
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			cliCtx.PrintResponse = true
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().StringSlice(flagDenoms, nil, "Denominations accepted in bids (defaults to the denominations of the min price)")
+	return cmd
+}
+
+// GetCmdSubmitCreateTLDProposal is the CLI command for submitting a CreateTLDProposal.
+// 它挂载在 gov 模块的 submit-proposal 命令下
+func GetCmdSubmitCreateTLDProposal(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create-tld [tld] [registrar] [min-price] [mode]",
+		Short: "submit a governance proposal to create a TLD",
+		Long: `Submit a governance proposal, along with an initial deposit, that creates a TLD
+such as dev with its own registrar and registration policy. The TLD is created when
+the proposal passes; afterwards its registrar maintains it with set-tld. The mode is
+one of open, auction or registrar.
+
+$ nscli tx gov submit-proposal create-tld dev cosmos1... 10nametoken open --title "Create dev" --description "..." --deposit 10stake --from alice
+`,
+		Args: cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc).WithAccountDecoder(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			registrar, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+
+			minPrice, err := sdk.ParseCoins(args[2])
+			if err != nil {
+				return err
+			}
+
+			denoms, err := cmd.Flags().GetStringSlice(flagDenoms)
+			if err != nil {
+				return err
+			}
+			if len(denoms) == 0 {
+				for _, coin := range minPrice {
+					denoms = append(denoms, coin.Denom)
+				}
+			}
+
+			deposit, err := sdk.ParseCoins(viper.GetString(govcli.FlagDeposit))
+			if err != nil {
+				return err
+			}
+
+			name, err := types.NormalizeName(args[0])
+			if err != nil {
+				return err
+			}
+
+			tld := types.NewTLD(name, registrar, minPrice, denoms, args[3])
+			content := types.NewCreateTLDProposal(viper.GetString(govcli.FlagTitle), viper.GetString(govcli.FlagDescription), tld)
+			msg := gov.NewMsgSubmitProposal(content, deposit, cliCtx.GetFromAddress())
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().String(govcli.FlagTitle, "", "title of the proposal")
+	cmd.Flags().String(govcli.FlagDescription, "", "description of the proposal")
+	cmd.Flags().String(govcli.FlagDeposit, "", "initial deposit of the proposal")
+	cmd.Flags().StringSlice(flagDenoms, nil, "Denominations accepted in bids (defaults to the denominations of the min price)")
+	return cmd
+}
+
+// GetCmdSetAlias is the CLI command for sending a SetAlias transaction
+func GetCmdSetAlias(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set-alias [name] [target]",
+		Short: "make a name you own an alias of another name, or remove its alias if no target is given",
+		Long: `Make a name you own an alias of another name. Resolving the name follows the
+chain of aliases, up to a bounded depth, to the value of the final name.`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc).WithAccountDecoder(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			if err := cliCtx.EnsureAccountExists(); err != nil {
+				return err
+			}
+
+			name, err := types.NormalizeName(args[0])
+			if err != nil {
+				return err
+			}
+
+			target := ""
+			if len(args) > 1 {
+				target, err = types.NormalizeName(args[1])
+				if err != nil {
+					return err
+				}
+			}
+
+			msg := types.NewMsgSetAlias(name, target, cliCtx.GetFromAddress())
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			cliCtx.PrintResponse = true
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdSetAddress is the CLI command for sending a SetAddress transaction
+func GetCmdSetAddress(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set-address [name] [coin-type] [address]",
+		Short: "set the address of a name you own on the chain of a SLIP-44 coin type, or remove it if no address is given",
+		Long: `Set the address of a name you own on another chain, identified by its SLIP-44
+coin type (e.g. 0 for Bitcoin, 60 for Ethereum, 118 for Cosmos chains).`,
+		Args: cobra.RangeArgs(2, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc).WithAccountDecoder(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			if err := cliCtx.EnsureAccountExists(); err != nil {
+				return err
+			}
+
+			name, err := types.NormalizeName(args[0])
+			if err != nil {
+				return err
+			}
+
+			coinType, err := strconv.ParseUint(args[1], 10, 32)
+			if err != nil {
+				return err
+			}
+			address := ""
+			if len(args) > 2 {
+				address = args[2]
+			}
+
+			msg := types.NewMsgSetAddress(name, uint32(coinType), address, cliCtx.GetFromAddress())
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			cliCtx.PrintResponse = true
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdSetText is the CLI command for sending a SetText transaction
+func GetCmdSetText(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set-text [name] [key] [value]",
+		Short: "set a text record of a name you own, or remove it if no value is given",
+		Long: `Set a key/value text record of a name you own, such as avatar, url, email,
+description or com.github.`,
+		Args: cobra.RangeArgs(2, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc).WithAccountDecoder(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			if err := cliCtx.EnsureAccountExists(); err != nil {
+				return err
+			}
+
+			name, err := types.NormalizeName(args[0])
+			if err != nil {
+				return err
+			}
+
+			value := ""
+			if len(args) > 2 {
+				value = args[2]
+			}
+
+			msg := types.NewMsgSetText(name, args[1], value, cliCtx.GetFromAddress())
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			cliCtx.PrintResponse = true
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdSetContentHash is the CLI command for sending a SetContentHash transaction
+func GetCmdSetContentHash(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set-content-hash [name] [content-hash]",
+		Short: "set the content hash of a name you own, or remove it if no content hash is given",
+		Long: `Set the content hash of a name you own, given as a URI such as ipfs://<cid>,
+ipns://<cid>, bzz://<hash> or onion3://<address>, or as 0x prefixed hex of its binary form.`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc).WithAccountDecoder(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			if err := cliCtx.EnsureAccountExists(); err != nil {
+				return err
+			}
+
+			name, err := types.NormalizeName(args[0])
+			if err != nil {
+				return err
+			}
+
+			var contentHash types.ContentHash
+			if len(args) > 1 {
+				parsed, err := types.ParseContentHash(args[1])
+				if err != nil {
+					return err
+				}
+				contentHash = parsed
+			}
+
+			msg := types.NewMsgSetContentHash(name, contentHash, cliCtx.GetFromAddress())
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			cliCtx.PrintResponse = true
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdSetGateway is the CLI command for sending a SetGateway transaction
+func GetCmdSetGateway(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set-gateway [name] [url] [signer]",
+		Short: "resolve a name you own through an off-chain gateway, or stop doing so if no gateway is given",
+		Long: `Redirect the resolution of a name you own to an off-chain gateway. Resolving
+the name fetches records from the gateway, which must be signed by the signer
+address; "{name}" in the URL is replaced by the name being resolved. The URL must
+be https and resolve to a public address.
+
+$ nscli tx nameservice set-gateway alice "https://gateway.example/{name}.json" cosmos1...`,
+		Args: cobra.RangeArgs(1, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc).WithAccountDecoder(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			if err := cliCtx.EnsureAccountExists(); err != nil {
+				return err
+			}
+
+			name, err := types.NormalizeName(args[0])
+			if err != nil {
+				return err
+			}
+
+			url := ""
+			var signer sdk.AccAddress
+			if len(args) > 1 {
+				if len(args) < 3 {
+					return fmt.Errorf("a gateway needs both a URL and a signer")
+				}
+				addr, err := sdk.AccAddressFromBech32(args[2])
+				if err != nil {
+					return err
+				}
+				url, signer = args[1], addr
+			}
+
+			msg := types.NewMsgSetGateway(name, url, signer, cliCtx.GetFromAddress())
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			cliCtx.PrintResponse = true
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
@@ -0,0 +1,81 @@
+package dns
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+const (
+	flagListen    = "listen"
+	flagZone      = "zone"
+	flagCacheTTL  = "cache-ttl"
+	flagCacheSize = "cache-size"
+
+	// FlagDNSZone is the rest-server flag setting the zone served at /dns-query
+	FlagDNSZone = "dns-zone"
+)
+
+// Defaults of the DNS server and of the DNS-over-HTTPS endpoint of the rest-server
+const (
+	DefaultZone      = "ns."
+	DefaultCacheTTL  = 30 * time.Second
+	DefaultCacheSize = 10000
+)
+
+// Command returns the dns command with its serve subcommand
+func Command(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	dnsCmd := &cobra.Command{
+		Use:   "dns",
+		Short: "Serve nameservice names over DNS",
+	}
+	dnsCmd.AddCommand(client.GetCommands(ServeCommand(queryRoute, cdc))...)
+	return dnsCmd
+}
+
+// RegisterRESTServerFlags adds the flags of the DNS-over-HTTPS endpoint to the rest-server command
+func RegisterRESTServerFlags(cmd *cobra.Command) *cobra.Command {
+	cmd.Flags().String(FlagDNSZone, DefaultZone, "zone the names are served under at /dns-query")
+	_ = viper.BindPFlag(FlagDNSZone, cmd.Flags().Lookup(FlagDNSZone))
+	return cmd
+}
+
+// NewNodeServer returns a server that queries the node of cliCtx
+func NewNodeServer(cliCtx context.CLIContext, zone string, cacheTTL time.Duration, queryRoute string) *Server {
+	return NewServer(zone, cacheTTL, cliCtx.QueryWithData, queryRoute, cliCtx.Codec)
+}
+
+// ServeCommand starts an authoritative DNS server answering for the names of the nameservice
+func ServeCommand(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Start an authoritative DNS server backed by chain state",
+		Long: `Start an authoritative DNS server answering UDP and TCP queries for the names
+below --zone. A query for alice.ns. is answered from the records of the name
+"alice"; names without an owner and expired names get NXDOMAIN.
+
+$ nscli dns serve --listen 127.0.0.1:5353 --zone ns. --chain-id namechain
+$ dig @127.0.0.1 -p 5353 alice.ns. A`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			server := NewNodeServer(cliCtx, viper.GetString(flagZone), viper.GetDuration(flagCacheTTL), queryRoute)
+			server.CacheSize = viper.GetInt(flagCacheSize)
+			defer server.Close()
+			addr := viper.GetString(flagListen)
+			fmt.Printf("serving zone %s on %s\n", server.Zone, addr)
+			return server.ListenAndServe(addr)
+		},
+	}
+	cmd.Flags().String(flagListen, "127.0.0.1:5353", "UDP and TCP address to listen on")
+	cmd.Flags().String(flagZone, DefaultZone, "zone the names are served under")
+	cmd.Flags().Duration(flagCacheTTL, DefaultCacheTTL, "how long answers are cached, 0 disables caching")
+	cmd.Flags().Int(flagCacheSize, DefaultCacheSize, "maximum number of names kept in the cache, 0 disables caching")
+	return cmd
+}
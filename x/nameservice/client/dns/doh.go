@@ -0,0 +1,211 @@
+package dns
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// DNS-over-HTTPS media types
+const (
+	MediaTypeDNSMessage = "application/dns-message" // RFC 8484 wire format
+	MediaTypeDNSJSON    = "application/dns-json"    // JSON format of Google and Cloudflare
+)
+
+// maximum size of a DNS message in wire format
+const maxMessageSize = 65535
+
+// ServeHTTP answers DNS-over-HTTPS queries. RFC 8484 queries are sent either as the
+// base64url "dns" parameter of a GET or as the body of a POST; JSON queries are GETs
+// with "name" and optionally "type" parameters.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodPost:
+		if r.Header.Get("Content-Type") != MediaTypeDNSMessage {
+			http.Error(w, "unsupported content type", http.StatusUnsupportedMediaType)
+			return
+		}
+		req, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxMessageSize))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		s.writeMessage(w, req)
+	case r.Method != http.MethodGet:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	case r.URL.Query().Get("dns") != "":
+		req, err := base64.RawURLEncoding.DecodeString(r.URL.Query().Get("dns"))
+		if err != nil {
+			http.Error(w, "invalid dns parameter", http.StatusBadRequest)
+			return
+		}
+		s.writeMessage(w, req)
+	case r.URL.Query().Get("name") != "":
+		s.writeJSON(w, r.URL.Query().Get("name"), r.URL.Query().Get("type"))
+	default:
+		http.Error(w, "missing dns or name parameter", http.StatusBadRequest)
+	}
+}
+
+// writeMessage answers a query in wire format
+func (s *Server) writeMessage(w http.ResponseWriter, req []byte) {
+	res := s.Handle(req)
+	if res == nil {
+		http.Error(w, "malformed DNS query", http.StatusBadRequest)
+		return
+	}
+	var msg dnsmessage.Message
+	if err := msg.Unpack(res); err == nil {
+		w.Header().Set("Cache-Control", fmt.Sprintf("max-age=%d", minTTL(msg)))
+	}
+	w.Header().Set("Content-Type", MediaTypeDNSMessage)
+	_, _ = w.Write(res)
+}
+
+// JSONQuestion is the question of a JSON DoH response
+type JSONQuestion struct {
+	Name string `json:"name"`
+	Type uint16 `json:"type"`
+}
+
+// JSONAnswer is a resource record of a JSON DoH response
+type JSONAnswer struct {
+	Name string `json:"name"`
+	Type uint16 `json:"type"`
+	TTL  uint32 `json:"TTL"`
+	Data string `json:"data"`
+}
+
+// JSONResponse is a DNS response in the JSON DoH format
+type JSONResponse struct {
+	Status    int            `json:"Status"`
+	TC        bool           `json:"TC"`
+	RD        bool           `json:"RD"`
+	RA        bool           `json:"RA"`
+	AD        bool           `json:"AD"`
+	CD        bool           `json:"CD"`
+	Question  []JSONQuestion `json:"Question"`
+	Answer    []JSONAnswer   `json:"Answer,omitempty"`
+	Authority []JSONAnswer   `json:"Authority,omitempty"`
+}
+
+// writeJSON answers a query in the JSON format
+func (s *Server) writeJSON(w http.ResponseWriter, name string, qtype string) {
+	t, err := parseType(qtype)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	qname, err := dnsmessage.NewName(Fqdn(name))
+	if err != nil {
+		http.Error(w, "invalid name", http.StatusBadRequest)
+		return
+	}
+	query := dnsmessage.Message{
+		Header:    dnsmessage.Header{RecursionDesired: true},
+		Questions: []dnsmessage.Question{{Name: qname, Type: t, Class: dnsmessage.ClassINET}},
+	}
+	req, err := query.Pack()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var msg dnsmessage.Message
+	if err := msg.Unpack(s.Handle(req)); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	res := JSONResponse{
+		Status:   int(msg.Header.RCode),
+		TC:       msg.Header.Truncated,
+		RD:       msg.Header.RecursionDesired,
+		RA:       msg.Header.RecursionAvailable,
+		Question: []JSONQuestion{{Name: qname.String(), Type: uint16(t)}},
+	}
+	for _, rr := range msg.Answers {
+		res.Answer = append(res.Answer, jsonAnswer(rr))
+	}
+	for _, rr := range msg.Authorities {
+		res.Authority = append(res.Authority, jsonAnswer(rr))
+	}
+	w.Header().Set("Cache-Control", fmt.Sprintf("max-age=%d", minTTL(msg)))
+	w.Header().Set("Content-Type", MediaTypeDNSJSON)
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+// parseType parses a query type given by name (e.g. "AAAA") or number, defaulting to A
+func parseType(s string) (dnsmessage.Type, error) {
+	switch strings.ToUpper(s) {
+	case "", "A":
+		return dnsmessage.TypeA, nil
+	case "AAAA":
+		return dnsmessage.TypeAAAA, nil
+	case "CNAME":
+		return dnsmessage.TypeCNAME, nil
+	case "MX":
+		return dnsmessage.TypeMX, nil
+	case "TXT":
+		return dnsmessage.TypeTXT, nil
+	case "SRV":
+		return dnsmessage.TypeSRV, nil
+	case "SOA":
+		return dnsmessage.TypeSOA, nil
+	case "ANY":
+		return dnsmessage.TypeALL, nil
+	}
+	n, err := strconv.ParseUint(s, 10, 16)
+	if err != nil {
+		return 0, fmt.Errorf("unsupported type %q", s)
+	}
+	return dnsmessage.Type(n), nil
+}
+
+// jsonAnswer converts a resource to its JSON form, with the data in presentation format
+func jsonAnswer(rr dnsmessage.Resource) JSONAnswer {
+	answer := JSONAnswer{Name: rr.Header.Name.String(), Type: uint16(rr.Header.Type), TTL: rr.Header.TTL}
+	switch body := rr.Body.(type) {
+	case *dnsmessage.AResource:
+		answer.Data = net.IP(body.A[:]).String()
+	case *dnsmessage.AAAAResource:
+		answer.Data = net.IP(body.AAAA[:]).String()
+	case *dnsmessage.CNAMEResource:
+		answer.Data = body.CNAME.String()
+	case *dnsmessage.MXResource:
+		answer.Data = fmt.Sprintf("%d %s", body.Pref, body.MX)
+	case *dnsmessage.TXTResource:
+		quoted := make([]string, len(body.TXT))
+		for i, txt := range body.TXT {
+			quoted[i] = strconv.Quote(txt)
+		}
+		answer.Data = strings.Join(quoted, " ")
+	case *dnsmessage.SRVResource:
+		answer.Data = fmt.Sprintf("%d %d %d %s", body.Priority, body.Weight, body.Port, body.Target)
+	case *dnsmessage.SOAResource:
+		answer.Data = fmt.Sprintf("%s %s %d %d %d %d %d",
+			body.NS, body.MBox, body.Serial, body.Refresh, body.Retry, body.Expire, body.MinTTL)
+	}
+	return answer
+}
+
+// minTTL returns the lowest TTL of the answers of a response, or of its SOA for negative answers
+func minTTL(msg dnsmessage.Message) uint32 {
+	rrs := msg.Answers
+	if len(rrs) == 0 {
+		rrs = msg.Authorities
+	}
+	var ttl uint32
+	for i, rr := range rrs {
+		if i == 0 || rr.Header.TTL < ttl {
+			ttl = rr.Header.TTL
+		}
+	}
+	return ttl
+}
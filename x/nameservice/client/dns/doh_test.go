@@ -0,0 +1,69 @@
+package dns_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/jerryma0912/Cosmos-sdk-tutorial/x/nameservice"
+	"github.com/jerryma0912/Cosmos-sdk-tutorial/x/nameservice/client/dns"
+)
+
+func TestDNSOverHTTPS(t *testing.T) {
+	ctx, keeper, query, cdc := createTestNode(t)
+	price := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 1))
+	keeper.SetWhois(ctx, "alice", nameservice.Whois{Value: "hello", Owner: owner, Price: price, Expires: 100})
+	keeper.SetRecords(ctx, "alice", nameservice.Records{nameservice.NewRecord("A", "192.0.2.1", 60)})
+
+	ts := httptest.NewServer(dns.NewServer("ns.", 0, query, nameservice.StoreKey, cdc))
+	defer ts.Close()
+
+	readMessage := func(res *http.Response) dnsmessage.Message {
+		defer res.Body.Close()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		require.Equal(t, dns.MediaTypeDNSMessage, res.Header.Get("Content-Type"))
+		bz, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		return parseResponse(t, bz)
+	}
+
+	// RFC 8484 GET
+	req := newQuery(t, "alice.ns.", dnsmessage.TypeA)
+	res, err := http.Get(ts.URL + "/dns-query?dns=" + base64.RawURLEncoding.EncodeToString(req))
+	require.NoError(t, err)
+	require.Equal(t, "max-age=60", res.Header.Get("Cache-Control"))
+	msg := readMessage(res)
+	require.Len(t, msg.Answers, 1)
+	require.Equal(t, [4]byte{192, 0, 2, 1}, msg.Answers[0].Body.(*dnsmessage.AResource).A)
+
+	// RFC 8484 POST
+	res, err = http.Post(ts.URL+"/dns-query", dns.MediaTypeDNSMessage, bytes.NewReader(newQuery(t, "bob.ns.", dnsmessage.TypeA)))
+	require.NoError(t, err)
+	require.Equal(t, dnsmessage.RCodeNameError, readMessage(res).Header.RCode)
+
+	res, err = http.Post(ts.URL+"/dns-query", "text/plain", bytes.NewReader(req))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusUnsupportedMediaType, res.StatusCode)
+	res.Body.Close()
+
+	// JSON
+	res, err = http.Get(ts.URL + "/dns-query?name=alice.ns&type=TXT")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, dns.MediaTypeDNSJSON, res.Header.Get("Content-Type"))
+	var out dns.JSONResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&out))
+	require.Equal(t, 0, out.Status)
+	require.Len(t, out.Answer, 1)
+	require.Equal(t, `"hello"`, out.Answer[0].Data)
+	require.Equal(t, uint16(dnsmessage.TypeTXT), out.Answer[0].Type)
+}
@@ -0,0 +1,455 @@
+// Package dns serves the names of the nameservice as an authoritative DNS zone.
+// Every query is turned into nameservice queries against a node, so that ordinary
+// resolvers and tools such as dig can resolve names registered on chain.
+package dns
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/jerryma0912/Cosmos-sdk-tutorial/x/nameservice/types"
+)
+
+const (
+	// maximum size of a response sent over UDP to a client without EDNS0
+	maxUDPSize = 512
+
+	// how long a TCP connection may stay idle between two queries
+	tcpIdleTimeout = 10 * time.Second
+
+	// maximum number of UDP queries answered concurrently. Further packets wait in the
+	// socket buffer and are dropped by the kernel once it is full.
+	maxUDPWorkers = 256
+)
+
+// QueryFunc runs an ABCI query against a node and returns the result together with the
+// height it was answered at, like context.CLIContext.QueryWithData
+type QueryFunc func(path string, data []byte) ([]byte, int64, error)
+
+// Server answers DNS queries for the names below Zone
+type Server struct {
+	Zone      string        // fully qualified, lower case zone the names are served under, e.g. "ns."
+	CacheTTL  time.Duration // how long answers are cached, zero disables caching
+	CacheSize int           // maximum number of cached names, zero disables caching
+
+	query      QueryFunc
+	queryRoute string
+	cdc        *codec.Codec
+
+	mtx       sync.Mutex
+	cache     map[string]cacheEntry
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// cacheEntry is the cached result of looking up a name on chain
+type cacheEntry struct {
+	owned   bool
+	records types.Records
+	expires time.Time
+}
+
+// NewServer returns a server answering queries for the names below zone by querying
+// the nameservice module mounted at queryRoute. When caching is enabled, expired
+// answers are swept from the cache every cacheTTL until the server is closed.
+func NewServer(zone string, cacheTTL time.Duration, query QueryFunc, queryRoute string, cdc *codec.Codec) *Server {
+	s := &Server{
+		Zone:       Fqdn(strings.ToLower(zone)),
+		CacheTTL:   cacheTTL,
+		CacheSize:  DefaultCacheSize,
+		query:      query,
+		queryRoute: queryRoute,
+		cdc:        cdc,
+		cache:      make(map[string]cacheEntry),
+		done:       make(chan struct{}),
+	}
+	if cacheTTL > 0 {
+		go s.sweepCache(cacheTTL)
+	}
+	return s
+}
+
+// Close stops sweeping the cache
+func (s *Server) Close() {
+	s.closeOnce.Do(func() { close(s.done) })
+}
+
+// Fqdn - returns name with a trailing dot
+func Fqdn(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
+
+// ListenAndServe answers queries on both UDP and TCP on addr until one of the listeners fails
+func (s *Server) ListenAndServe(addr string) error {
+	conn, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer ln.Close()
+
+	errs := make(chan error, 2)
+	go func() { errs <- s.ServePacket(conn) }()
+	go func() { errs <- s.ServeTCP(ln) }()
+	return <-errs
+}
+
+// ServePacket answers the UDP queries read from conn until it is closed
+func (s *Server) ServePacket(conn net.PacketConn) error {
+	buf := make([]byte, 65535)
+	// 限制并发处理的 UDP 查询数
+	workers := make(chan struct{}, maxUDPWorkers)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			return err
+		}
+		req := make([]byte, n)
+		copy(req, buf[:n])
+		workers <- struct{}{}
+		go func() {
+			defer func() { <-workers }()
+			if res := s.handle(req, maxUDPSize); res != nil {
+				_, _ = conn.WriteTo(res, addr)
+			}
+		}()
+	}
+}
+
+// ServeTCP answers the length-prefixed TCP queries of the connections accepted on ln until it is closed
+func (s *Server) ServeTCP(ln net.Listener) error {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return err
+		}
+		go s.serveConn(conn)
+	}
+}
+
+func (s *Server) serveConn(conn net.Conn) {
+	defer conn.Close()
+	for {
+		_ = conn.SetReadDeadline(time.Now().Add(tcpIdleTimeout))
+		var length [2]byte
+		if _, err := io.ReadFull(conn, length[:]); err != nil {
+			return
+		}
+		req := make([]byte, binary.BigEndian.Uint16(length[:]))
+		if _, err := io.ReadFull(conn, req); err != nil {
+			return
+		}
+		res := s.handle(req, 0)
+		if res == nil {
+			return
+		}
+		binary.BigEndian.PutUint16(length[:], uint16(len(res)))
+		if _, err := conn.Write(append(length[:], res...)); err != nil {
+			return
+		}
+	}
+}
+
+// Handle answers a DNS query in wire format. It returns nil if the query cannot even be
+// parsed far enough to send an error back.
+func (s *Server) Handle(req []byte) []byte {
+	return s.handle(req, 0)
+}
+
+// handle answers a query, truncating the answer to maxSize bytes if maxSize is not zero
+func (s *Server) handle(req []byte, maxSize int) []byte {
+	var p dnsmessage.Parser
+	h, err := p.Start(req)
+	if err != nil || h.Response {
+		return nil
+	}
+	res := dnsmessage.Header{
+		ID:               h.ID,
+		Response:         true,
+		OpCode:           h.OpCode,
+		RecursionDesired: h.RecursionDesired,
+	}
+	q, err := p.Question()
+	if err != nil {
+		res.RCode = dnsmessage.RCodeFormatError
+		return build(res, nil, nil, nil)
+	}
+	if h.OpCode != 0 {
+		res.RCode = dnsmessage.RCodeNotImplemented
+		return build(res, &q, nil, nil)
+	}
+
+	answers, authorities, rcode := s.answer(q)
+	res.RCode = rcode
+	res.Authoritative = rcode != dnsmessage.RCodeRefused && rcode != dnsmessage.RCodeServerFailure
+	msg := build(res, &q, answers, authorities)
+	if maxSize > 0 && len(msg) > maxSize {
+		// the client has to retry over TCP to get the full answer
+		res.Truncated = true
+		msg = build(res, &q, nil, nil)
+	}
+	return msg
+}
+
+// answer resolves a question to its answer and authority sections
+func (s *Server) answer(q dnsmessage.Question) (answers, authorities []dnsmessage.Resource, rcode dnsmessage.RCode) {
+	qname := strings.ToLower(q.Name.String())
+	if q.Class != dnsmessage.ClassINET && q.Class != dnsmessage.ClassANY {
+		return nil, nil, dnsmessage.RCodeRefused
+	}
+	if qname == s.Zone {
+		if q.Type == dnsmessage.TypeSOA || q.Type == dnsmessage.TypeALL {
+			return []dnsmessage.Resource{s.soa()}, nil, dnsmessage.RCodeSuccess
+		}
+		return nil, []dnsmessage.Resource{s.soa()}, dnsmessage.RCodeSuccess
+	}
+	if !strings.HasSuffix(qname, "."+s.Zone) {
+		return nil, nil, dnsmessage.RCodeRefused
+	}
+
+	name := strings.TrimSuffix(qname, "."+s.Zone)
+	if !types.IsValidHostname(name) {
+		return nil, []dnsmessage.Resource{s.soa()}, dnsmessage.RCodeNameError
+	}
+	owned, records, err := s.lookup(name)
+	if err != nil {
+		return nil, nil, dnsmessage.RCodeServerFailure
+	}
+	if !owned {
+		return nil, []dnsmessage.Resource{s.soa()}, dnsmessage.RCodeNameError
+	}
+
+	// 与 DNS 一样，存在 CNAME 记录时对其他类型的查询返回 CNAME，由解析器继续跟随
+	if cnames := records.Filter(types.RecordTypeCNAME); len(cnames) > 0 {
+		records = cnames
+	} else if q.Type != dnsmessage.TypeALL {
+		records = records.Filter(recordType(q.Type))
+	}
+	for _, record := range records {
+		if rr, ok := resource(q.Name, record); ok {
+			answers = append(answers, rr)
+		}
+	}
+	if len(answers) == 0 {
+		return nil, []dnsmessage.Resource{s.soa()}, dnsmessage.RCodeSuccess
+	}
+	return answers, nil, dnsmessage.RCodeSuccess
+}
+
+// lookup returns whether a name is owned and its records, from the cache if possible.
+// Expired names are not owned, even before the chain releases them.
+func (s *Server) lookup(name string) (owned bool, records types.Records, err error) {
+	now := time.Now()
+	s.mtx.Lock()
+	entry, ok := s.cache[name]
+	if ok && !now.Before(entry.expires) {
+		delete(s.cache, name)
+		ok = false
+	}
+	s.mtx.Unlock()
+	if ok {
+		return entry.owned, entry.records, nil
+	}
+
+	res, height, err := s.query(fmt.Sprintf("custom/%s/whois/%s", s.queryRoute, name), nil)
+	if err != nil {
+		return false, nil, err
+	}
+	var whois types.Whois
+	if err := s.cdc.UnmarshalJSON(res, &whois); err != nil {
+		return false, nil, err
+	}
+	// 不存在的域名仍可能由通配符 *.parent 应答
+	res, _, err = s.query(fmt.Sprintf("custom/%s/records/%s", s.queryRoute, name), nil)
+	if err != nil {
+		return false, nil, err
+	}
+	var out types.QueryResRecords
+	if err := s.cdc.UnmarshalJSON(res, &out); err != nil {
+		return false, nil, err
+	}
+	if owned = (!whois.Owner.Empty() && !whois.IsExpired(height)) || out.Wildcard != ""; owned {
+		records = out.Records
+	}
+
+	if s.CacheTTL > 0 && s.CacheSize > 0 {
+		s.mtx.Lock()
+		s.makeRoom(name, now)
+		s.cache[name] = cacheEntry{owned: owned, records: records, expires: now.Add(s.CacheTTL)}
+		s.mtx.Unlock()
+	}
+	return owned, records, nil
+}
+
+// makeRoom evicts entries until name fits into the cache: expired entries first, then
+// arbitrary ones. The caller must hold s.mtx.
+func (s *Server) makeRoom(name string, now time.Time) {
+	if _, ok := s.cache[name]; ok || len(s.cache) < s.CacheSize {
+		return
+	}
+	s.evictExpired(now)
+	for cached := range s.cache {
+		if len(s.cache) < s.CacheSize {
+			return
+		}
+		delete(s.cache, cached)
+	}
+}
+
+// evictExpired removes the expired entries from the cache. The caller must hold s.mtx.
+func (s *Server) evictExpired(now time.Time) {
+	for name, entry := range s.cache {
+		if !now.Before(entry.expires) {
+			delete(s.cache, name)
+		}
+	}
+}
+
+// sweepCache periodically removes the expired entries from the cache until the server is closed
+func (s *Server) sweepCache(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case now := <-ticker.C:
+			s.mtx.Lock()
+			s.evictExpired(now)
+			s.mtx.Unlock()
+		}
+	}
+}
+
+// soa returns the SOA record of the zone, sent along with negative answers
+func (s *Server) soa() dnsmessage.Resource {
+	zone := dnsmessage.MustNewName(s.Zone)
+	ttl := uint32(s.CacheTTL / time.Second)
+	return dnsmessage.Resource{
+		Header: dnsmessage.ResourceHeader{Name: zone, Type: dnsmessage.TypeSOA, Class: dnsmessage.ClassINET, TTL: ttl},
+		Body: &dnsmessage.SOAResource{
+			NS:      zone,
+			MBox:    dnsmessage.MustNewName("hostmaster." + s.Zone),
+			Serial:  1,
+			Refresh: 3600,
+			Retry:   600,
+			Expire:  86400,
+			MinTTL:  ttl,
+		},
+	}
+}
+
+// recordType maps a DNS query type to the nameservice record type
+func recordType(t dnsmessage.Type) string {
+	switch t {
+	case dnsmessage.TypeA:
+		return types.RecordTypeA
+	case dnsmessage.TypeAAAA:
+		return types.RecordTypeAAAA
+	case dnsmessage.TypeCNAME:
+		return types.RecordTypeCNAME
+	case dnsmessage.TypeMX:
+		return types.RecordTypeMX
+	case dnsmessage.TypeTXT:
+		return types.RecordTypeTXT
+	case dnsmessage.TypeSRV:
+		return types.RecordTypeSRV
+	default:
+		return ""
+	}
+}
+
+// resource converts a validated nameservice record to a DNS resource of the given owner name
+func resource(owner dnsmessage.Name, record types.Record) (dnsmessage.Resource, bool) {
+	h := dnsmessage.ResourceHeader{Name: owner, Class: dnsmessage.ClassINET, TTL: record.TTL}
+	var body dnsmessage.ResourceBody
+	switch record.Type {
+	case types.RecordTypeA:
+		h.Type = dnsmessage.TypeA
+		var a dnsmessage.AResource
+		copy(a.A[:], net.ParseIP(record.Value).To4())
+		body = &a
+	case types.RecordTypeAAAA:
+		h.Type = dnsmessage.TypeAAAA
+		var aaaa dnsmessage.AAAAResource
+		copy(aaaa.AAAA[:], net.ParseIP(record.Value).To16())
+		body = &aaaa
+	case types.RecordTypeCNAME:
+		target, err := dnsmessage.NewName(Fqdn(record.Value))
+		if err != nil {
+			return dnsmessage.Resource{}, false
+		}
+		h.Type = dnsmessage.TypeCNAME
+		body = &dnsmessage.CNAMEResource{CNAME: target}
+	case types.RecordTypeMX:
+		fields := strings.Fields(record.Value)
+		pref, _ := strconv.ParseUint(fields[0], 10, 16)
+		exchange, err := dnsmessage.NewName(Fqdn(fields[1]))
+		if err != nil {
+			return dnsmessage.Resource{}, false
+		}
+		h.Type = dnsmessage.TypeMX
+		body = &dnsmessage.MXResource{Pref: uint16(pref), MX: exchange}
+	case types.RecordTypeTXT:
+		h.Type = dnsmessage.TypeTXT
+		body = &dnsmessage.TXTResource{TXT: splitTXT(record.Value)}
+	case types.RecordTypeSRV:
+		fields := strings.Fields(record.Value)
+		var nums [3]uint16
+		for i := range nums {
+			n, _ := strconv.ParseUint(fields[i], 10, 16)
+			nums[i] = uint16(n)
+		}
+		target, err := dnsmessage.NewName(Fqdn(fields[3]))
+		if err != nil {
+			return dnsmessage.Resource{}, false
+		}
+		h.Type = dnsmessage.TypeSRV
+		body = &dnsmessage.SRVResource{Priority: nums[0], Weight: nums[1], Port: nums[2], Target: target}
+	default:
+		return dnsmessage.Resource{}, false
+	}
+	return dnsmessage.Resource{Header: h, Body: body}, true
+}
+
+// splitTXT splits text into the at most 255 byte character strings of a TXT record
+func splitTXT(text string) []string {
+	var parts []string
+	for len(text) > 255 {
+		parts = append(parts, text[:255])
+		text = text[255:]
+	}
+	return append(parts, text)
+}
+
+// build encodes a response message
+func build(h dnsmessage.Header, q *dnsmessage.Question, answers, authorities []dnsmessage.Resource) []byte {
+	msg := dnsmessage.Message{Header: h, Answers: answers, Authorities: authorities}
+	if q != nil {
+		msg.Questions = []dnsmessage.Question{*q}
+	}
+	bz, err := msg.Pack()
+	if err != nil {
+		// only happens for records that passed validation but cannot be encoded
+		msg.Header.RCode = dnsmessage.RCodeServerFailure
+		msg.Answers, msg.Authorities = nil, nil
+		bz, _ = msg.Pack()
+	}
+	return bz
+}
@@ -0,0 +1,242 @@
+package dns_test
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/jerryma0912/Cosmos-sdk-tutorial/x/nameservice"
+	"github.com/jerryma0912/Cosmos-sdk-tutorial/x/nameservice/client/dns"
+)
+
+var owner = sdk.AccAddress([]byte("owner_______________"))
+
+// createTestNode returns a context and keeper together with a QueryFunc that runs
+// queries through the nameservice querier, like a node would
+func createTestNode(t *testing.T) (sdk.Context, nameservice.Keeper, dns.QueryFunc, *codec.Codec) {
+	ctx, keeper := nameservice.CreateTestInput(t)
+	querier := nameservice.NewQuerier(keeper)
+	query := func(path string, data []byte) ([]byte, int64, error) {
+		route := strings.TrimPrefix(path, "custom/"+nameservice.StoreKey+"/")
+		res, err := querier(ctx, strings.Split(route, "/"), abci.RequestQuery{Data: data})
+		if err != nil {
+			return nil, 0, err
+		}
+		return res, ctx.BlockHeight(), nil
+	}
+	return ctx, keeper, query, nameservice.ModuleCdc
+}
+
+func newQuery(t *testing.T, name string, qtype dnsmessage.Type) []byte {
+	msg := dnsmessage.Message{
+		Header: dnsmessage.Header{ID: 42, RecursionDesired: true},
+		Questions: []dnsmessage.Question{{
+			Name:  dnsmessage.MustNewName(name),
+			Type:  qtype,
+			Class: dnsmessage.ClassINET,
+		}},
+	}
+	bz, err := msg.Pack()
+	require.NoError(t, err)
+	return bz
+}
+
+func parseResponse(t *testing.T, bz []byte) dnsmessage.Message {
+	var msg dnsmessage.Message
+	require.NoError(t, msg.Unpack(bz))
+	require.True(t, msg.Header.Response)
+	require.Equal(t, uint16(42), msg.Header.ID)
+	return msg
+}
+
+func TestServerAnswers(t *testing.T) {
+	ctx, keeper, query, cdc := createTestNode(t)
+	price := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 1))
+	keeper.SetWhois(ctx, "alice", nameservice.Whois{Value: "hello", Owner: owner, Price: price, Expires: 100})
+	keeper.SetRecords(ctx, "alice", nameservice.Records{
+		nameservice.NewRecord("A", "192.0.2.1", 60),
+		nameservice.NewRecord("AAAA", "2001:db8::1", 60),
+		nameservice.NewRecord("MX", "10 mail.example.com", 60),
+	})
+	keeper.SetWhois(ctx, "www", nameservice.Whois{Owner: owner, Price: price, Expires: 100})
+	keeper.SetRecords(ctx, "www", nameservice.Records{nameservice.NewRecord("CNAME", "alice.ns.", 60)})
+
+	server := dns.NewServer("NS", 0, query, nameservice.StoreKey, cdc)
+
+	res := parseResponse(t, server.Handle(newQuery(t, "alice.ns.", dnsmessage.TypeA)))
+	require.Equal(t, dnsmessage.RCodeSuccess, res.Header.RCode)
+	require.True(t, res.Header.Authoritative)
+	require.Len(t, res.Answers, 1)
+	require.Equal(t, [4]byte{192, 0, 2, 1}, res.Answers[0].Body.(*dnsmessage.AResource).A)
+	require.Equal(t, uint32(60), res.Answers[0].Header.TTL)
+
+	res = parseResponse(t, server.Handle(newQuery(t, "ALICE.ns.", dnsmessage.TypeTXT)))
+	require.Len(t, res.Answers, 1)
+	require.Equal(t, []string{"hello"}, res.Answers[0].Body.(*dnsmessage.TXTResource).TXT)
+
+	res = parseResponse(t, server.Handle(newQuery(t, "alice.ns.", dnsmessage.TypeMX)))
+	require.Len(t, res.Answers, 1)
+	require.Equal(t, "mail.example.com.", res.Answers[0].Body.(*dnsmessage.MXResource).MX.String())
+
+	res = parseResponse(t, server.Handle(newQuery(t, "www.ns.", dnsmessage.TypeA)))
+	require.Len(t, res.Answers, 1)
+	require.Equal(t, "alice.ns.", res.Answers[0].Body.(*dnsmessage.CNAMEResource).CNAME.String())
+
+	// owned name without records of the type: NOERROR without answers
+	res = parseResponse(t, server.Handle(newQuery(t, "alice.ns.", dnsmessage.TypeSRV)))
+	require.Equal(t, dnsmessage.RCodeSuccess, res.Header.RCode)
+	require.Empty(t, res.Answers)
+	require.Len(t, res.Authorities, 1)
+
+	res = parseResponse(t, server.Handle(newQuery(t, "bob.ns.", dnsmessage.TypeA)))
+	require.Equal(t, dnsmessage.RCodeNameError, res.Header.RCode)
+
+	// subdomains of alice that do not exist are answered by the *.alice wildcard
+	keeper.SetWhois(ctx, "*.alice", nameservice.Whois{Owner: owner, Price: price, Expires: 100})
+	keeper.SetRecords(ctx, "*.alice", nameservice.Records{nameservice.NewRecord("A", "192.0.2.9", 60)})
+	res = parseResponse(t, server.Handle(newQuery(t, "customer.alice.ns.", dnsmessage.TypeA)))
+	require.Len(t, res.Answers, 1)
+	require.Equal(t, "customer.alice.ns.", res.Answers[0].Header.Name.String())
+	require.Equal(t, [4]byte{192, 0, 2, 9}, res.Answers[0].Body.(*dnsmessage.AResource).A)
+
+	res = parseResponse(t, server.Handle(newQuery(t, "alice.example.", dnsmessage.TypeA)))
+	require.Equal(t, dnsmessage.RCodeRefused, res.Header.RCode)
+}
+
+func TestServerExpiredNames(t *testing.T) {
+	ctx, keeper, query, cdc := createTestNode(t)
+	price := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 1))
+	// 测试节点的高度为 1，Expires 为 0 的域名已过期但尚未被释放
+	keeper.SetWhois(ctx, "alice", nameservice.Whois{Value: "hello", Owner: owner, Price: price, Expires: 0})
+	keeper.SetRecords(ctx, "alice", nameservice.Records{nameservice.NewRecord("A", "192.0.2.1", 60)})
+	keeper.SetWhois(ctx, "bob", nameservice.Whois{Owner: owner, Price: price, Expires: 100})
+	keeper.SetWhois(ctx, "*.bob", nameservice.Whois{Owner: owner, Price: price, Expires: 0})
+	keeper.SetRecords(ctx, "*.bob", nameservice.Records{nameservice.NewRecord("A", "192.0.2.9", 60)})
+
+	server := dns.NewServer("ns.", 0, query, nameservice.StoreKey, cdc)
+	for _, qtype := range []dnsmessage.Type{dnsmessage.TypeA, dnsmessage.TypeTXT} {
+		res := parseResponse(t, server.Handle(newQuery(t, "alice.ns.", qtype)))
+		require.Equal(t, dnsmessage.RCodeNameError, res.Header.RCode)
+		require.Empty(t, res.Answers)
+	}
+	// an expired wildcard no longer answers for the subdomains of its parent
+	res := parseResponse(t, server.Handle(newQuery(t, "customer.bob.ns.", dnsmessage.TypeA)))
+	require.Equal(t, dnsmessage.RCodeNameError, res.Header.RCode)
+
+	// renewing the name serves it again
+	keeper.SetWhois(ctx, "alice", nameservice.Whois{Value: "hello", Owner: owner, Price: price, Expires: 100})
+	res = parseResponse(t, server.Handle(newQuery(t, "alice.ns.", dnsmessage.TypeA)))
+	require.Equal(t, dnsmessage.RCodeSuccess, res.Header.RCode)
+	require.Len(t, res.Answers, 1)
+}
+
+func TestServerCache(t *testing.T) {
+	ctx, keeper, query, cdc := createTestNode(t)
+	price := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 1))
+	keeper.SetWhois(ctx, "alice", nameservice.Whois{Owner: owner, Price: price, Expires: 100})
+	keeper.SetRecords(ctx, "alice", nameservice.Records{nameservice.NewRecord("A", "192.0.2.1", 60)})
+
+	cached := dns.NewServer("ns.", time.Hour, query, nameservice.StoreKey, cdc)
+	uncached := dns.NewServer("ns.", 0, query, nameservice.StoreKey, cdc)
+	require.Len(t, parseResponse(t, cached.Handle(newQuery(t, "alice.ns.", dnsmessage.TypeA))).Answers, 1)
+
+	keeper.DeleteWhois(ctx, "alice")
+	require.Len(t, parseResponse(t, cached.Handle(newQuery(t, "alice.ns.", dnsmessage.TypeA))).Answers, 1)
+	res := parseResponse(t, uncached.Handle(newQuery(t, "alice.ns.", dnsmessage.TypeA)))
+	require.Equal(t, dnsmessage.RCodeNameError, res.Header.RCode)
+}
+
+func TestServerCacheEviction(t *testing.T) {
+	ctx, keeper, query, cdc := createTestNode(t)
+	price := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 1))
+	keeper.SetWhois(ctx, "alice", nameservice.Whois{Owner: owner, Price: price, Expires: 100})
+	keeper.SetRecords(ctx, "alice", nameservice.Records{nameservice.NewRecord("A", "192.0.2.1", 60)})
+	keeper.SetWhois(ctx, "bob", nameservice.Whois{Owner: owner, Price: price, Expires: 100})
+	keeper.SetRecords(ctx, "bob", nameservice.Records{nameservice.NewRecord("A", "192.0.2.2", 60)})
+
+	// a full cache makes room for new names
+	server := dns.NewServer("ns.", time.Hour, query, nameservice.StoreKey, cdc)
+	defer server.Close()
+	server.CacheSize = 1
+	require.Len(t, parseResponse(t, server.Handle(newQuery(t, "alice.ns.", dnsmessage.TypeA))).Answers, 1)
+	require.Len(t, parseResponse(t, server.Handle(newQuery(t, "bob.ns.", dnsmessage.TypeA))).Answers, 1)
+	keeper.DeleteWhois(ctx, "alice")
+	keeper.DeleteWhois(ctx, "bob")
+	res := parseResponse(t, server.Handle(newQuery(t, "alice.ns.", dnsmessage.TypeA)))
+	require.Equal(t, dnsmessage.RCodeNameError, res.Header.RCode)
+
+	// expired answers are not served
+	keeper.SetWhois(ctx, "carol", nameservice.Whois{Owner: owner, Price: price, Expires: 100})
+	keeper.SetRecords(ctx, "carol", nameservice.Records{nameservice.NewRecord("A", "192.0.2.3", 60)})
+	short := dns.NewServer("ns.", 10*time.Millisecond, query, nameservice.StoreKey, cdc)
+	defer short.Close()
+	require.Len(t, parseResponse(t, short.Handle(newQuery(t, "carol.ns.", dnsmessage.TypeA))).Answers, 1)
+	keeper.DeleteWhois(ctx, "carol")
+	time.Sleep(20 * time.Millisecond)
+	res = parseResponse(t, short.Handle(newQuery(t, "carol.ns.", dnsmessage.TypeA)))
+	require.Equal(t, dnsmessage.RCodeNameError, res.Header.RCode)
+}
+
+func TestServerTransports(t *testing.T) {
+	ctx, keeper, query, cdc := createTestNode(t)
+	price := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 1))
+	keeper.SetWhois(ctx, "alice", nameservice.Whois{Owner: owner, Price: price, Expires: 100})
+	keeper.SetRecords(ctx, "alice", nameservice.Records{
+		nameservice.NewRecord("TXT", strings.Repeat("a", 250), 60),
+		nameservice.NewRecord("TXT", strings.Repeat("b", 250), 60),
+		nameservice.NewRecord("TXT", strings.Repeat("c", 250), 60),
+	})
+	server := dns.NewServer("ns.", 0, query, nameservice.StoreKey, cdc)
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+	go server.ServePacket(conn) // nolint: errcheck
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	go server.ServeTCP(ln) // nolint: errcheck
+
+	// the answer does not fit into a UDP response, so it is truncated
+	client, err := net.Dial("udp", conn.LocalAddr().String())
+	require.NoError(t, err)
+	defer client.Close()
+	_, err = client.Write(newQuery(t, "alice.ns.", dnsmessage.TypeTXT))
+	require.NoError(t, err)
+	buf := make([]byte, 512)
+	require.NoError(t, client.SetReadDeadline(time.Now().Add(5*time.Second)))
+	n, err := client.Read(buf)
+	require.NoError(t, err)
+	res := parseResponse(t, buf[:n])
+	require.True(t, res.Header.Truncated)
+	require.Empty(t, res.Answers)
+
+	// and retried over TCP
+	tcp, err := net.Dial("tcp", ln.Addr().String())
+	require.NoError(t, err)
+	defer tcp.Close()
+	req := newQuery(t, "alice.ns.", dnsmessage.TypeTXT)
+	length := make([]byte, 2)
+	binary.BigEndian.PutUint16(length, uint16(len(req)))
+	_, err = tcp.Write(append(length, req...))
+	require.NoError(t, err)
+	require.NoError(t, tcp.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, err = io.ReadFull(tcp, length)
+	require.NoError(t, err)
+	bz := make([]byte, binary.BigEndian.Uint16(length))
+	_, err = io.ReadFull(tcp, bz)
+	require.NoError(t, err)
+	res = parseResponse(t, bz)
+	require.False(t, res.Header.Truncated)
+	require.Len(t, res.Answers, 3)
+}
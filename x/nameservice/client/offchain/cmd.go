@@ -0,0 +1,135 @@
+package offchain
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/keys"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/tendermint/tendermint/crypto"
+)
+
+// FlagFetchGateways is the rest-server flag that lets it resolve names through their
+// off-chain gateways. It is off by default, so that a rest-server only makes outbound
+// requests to gateway URLs set on chain when its operator opts in.
+const FlagFetchGateways = "fetch-gateways"
+
+// RegisterRESTServerFlags adds the flags of gateway resolution to the rest-server command
+func RegisterRESTServerFlags(cmd *cobra.Command) *cobra.Command {
+	cmd.Flags().Bool(FlagFetchGateways, false, "resolve names that have a gateway by querying the gateway over https")
+	_ = viper.BindPFlag(FlagFetchGateways, cmd.Flags().Lookup(FlagFetchGateways))
+	return cmd
+}
+
+// Command returns the records command with its sign and verify subcommands
+func Command(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	recordsCmd := &cobra.Command{
+		Use:   "records",
+		Short: "Sign and verify records of a name published off chain",
+	}
+	recordsCmd.AddCommand(SignCommand(cdc))
+	recordsCmd.AddCommand(client.GetCommands(VerifyCommand(queryRoute, cdc))...)
+	return recordsCmd
+}
+
+// SignCommand signs a document with a key of the local keybase
+func SignCommand(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sign [document-file]",
+		Short: "Sign an off-chain records document with the key of the owner of its name",
+		Long: `Sign a JSON document with the name, records, sequence and expiry of a name,
+read from a file or from stdin when the file is "-". The signed document is printed.
+
+$ cat alice.json
+{"name":"alice","records":[{"type":"A","value":"192.0.2.1","ttl":60}],"sequence":"1","expires":"2030-01-01T00:00:00Z"}
+$ nscli records sign alice.json --from alice > alice.signed.json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bz, err := readFile(args[0])
+			if err != nil {
+				return err
+			}
+			var doc Document
+			if err := cdc.UnmarshalJSON(bz, &doc); err != nil {
+				return err
+			}
+
+			from, err := cmd.Flags().GetString(client.FlagFrom)
+			if err != nil {
+				return err
+			}
+			kb, err := keys.NewKeyBaseFromHomeFlag()
+			if err != nil {
+				return err
+			}
+			signer := func(msg []byte) ([]byte, crypto.PubKey, error) {
+				passphrase, err := keys.GetPassphrase(from)
+				if err != nil {
+					return nil, nil, err
+				}
+				return kb.Sign(from, passphrase, msg)
+			}
+			signed, err := Sign(doc, signer)
+			if err != nil {
+				return err
+			}
+
+			out, err := codec.MarshalJSONIndent(cdc, signed)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(out))
+			return nil
+		},
+	}
+	cmd.Flags().String(client.FlagFrom, "", "Name of the key to sign with")
+	return cmd
+}
+
+// VerifyCommand verifies a signed document against the owner of its name on chain
+func VerifyCommand(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "verify [signed-document-file]",
+		Short: "Verify a signed off-chain records document against the current owner of its name",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			bz, err := readFile(args[0])
+			if err != nil {
+				return err
+			}
+			var signed SignedDocument
+			if err := cdc.UnmarshalJSON(bz, &signed); err != nil {
+				return err
+			}
+
+			res, err := VerifyWithChain(signed, cliCtx.QueryWithData, queryRoute, cdc, time.Now())
+			if err != nil {
+				return err
+			}
+			if err := cliCtx.PrintOutput(res); err != nil {
+				return err
+			}
+			if !res.Valid {
+				return ErrVerificationFailed
+			}
+			return nil
+		},
+	}
+}
+
+// readFile - reads a file, or stdin if the name is "-"
+func readFile(name string) ([]byte, error) {
+	if name == "-" {
+		return ioutil.ReadAll(os.Stdin)
+	}
+	return ioutil.ReadFile(name)
+}
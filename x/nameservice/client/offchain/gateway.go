@@ -0,0 +1,174 @@
+package offchain
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+
+	"github.com/jerryma0912/Cosmos-sdk-tutorial/x/nameservice/types"
+)
+
+const (
+	// maximum size of the answer of a gateway
+	maxAnswerSize = 1 << 20
+
+	// maximum number of redirects followed when querying a gateway
+	maxRedirects = 5
+)
+
+// DefaultClient is the HTTP client gateways are queried with
+var DefaultClient = NewClient(10 * time.Second)
+
+// 网关 URL 由域名所有者写在链上，任何人都可以设置。为了不让 rest-server 或 nscli
+// 替攻击者访问内网服务，客户端只通过 https 连接公网地址，跟随重定向时同样检查。
+// 地址在建立连接时检查，所以解析到内网地址的域名（包括 DNS rebinding）也会被拒绝。
+var nonPublicNets = parseCIDRs(
+	"0.0.0.0/8",      // "this" network
+	"10.0.0.0/8",     // private
+	"100.64.0.0/10",  // carrier-grade NAT
+	"127.0.0.0/8",    // loopback
+	"169.254.0.0/16", // link-local
+	"172.16.0.0/12",  // private
+	"192.0.0.0/24",   // IETF protocol assignments
+	"192.168.0.0/16", // private
+	"198.18.0.0/15",  // benchmarking
+	"224.0.0.0/4",    // multicast
+	"240.0.0.0/4",    // reserved and broadcast
+	"::/128",         // unspecified
+	"::1/128",        // loopback
+	"64:ff9b:1::/48", // local-use NAT64
+	"fc00::/7",       // unique local
+	"fe80::/10",      // link-local
+	"ff00::/8",       // multicast
+)
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	nets := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		nets[i] = n
+	}
+	return nets
+}
+
+// IsPublicIP - returns whether ip is a globally routable address gateways may be queried at
+func IsPublicIP(ip net.IP) bool {
+	for _, n := range nonPublicNets {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// NewClient returns an HTTP client that only connects to public addresses over https,
+// also when following redirects. Proxies from the environment are not used, since the
+// client could not check the addresses they connect to.
+func NewClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: refuseNonPublic}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+		},
+		CheckRedirect: checkRedirect,
+	}
+}
+
+// refuseNonPublic is the control function of the dialer of NewClient. It sees the
+// resolved address right before the connection is made.
+func refuseNonPublic(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !IsPublicIP(ip) {
+		return fmt.Errorf("refusing to connect to non-public address %s", host)
+	}
+	return nil
+}
+
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+	if req.URL.Scheme != "https" {
+		return fmt.Errorf("refusing to follow redirect to non-https URL %s", req.URL)
+	}
+	return nil
+}
+
+// ErrNotGatewaySigner is returned for gateway answers not signed by the signer of the gateway
+var ErrNotGatewaySigner = errors.New("answer is not signed by the signer of the gateway")
+
+// Answer is the verified answer of a gateway for a name
+type Answer struct {
+	Name     string        `json:"name"`
+	Gateway  types.Gateway `json:"gateway"`
+	Records  types.Records `json:"records"`
+	Sequence uint64        `json:"sequence"`
+	Expires  time.Time     `json:"expires"`
+}
+
+// implement fmt.Stringer
+func (a Answer) String() string {
+	return fmt.Sprintf("; answered by gateway %s\n%s", a.Gateway, a.Records)
+}
+
+// FetchAnswer queries the gateway for the records of name and verifies that the answer
+// is a document for the name signed by the signer of the gateway and not expired at now.
+// The gateway is expected to answer a GET of Gateway.QueryURL, which must be an https
+// URL, with a SignedDocument.
+func FetchAnswer(client *http.Client, gateway types.Gateway, name string, cdc *codec.Codec, now time.Time) (Answer, error) {
+	// 旧的网关可能是在只允许 https 之前设置的
+	queryURL := gateway.QueryURL(name)
+	if u, err := url.Parse(queryURL); err != nil || u.Scheme != "https" {
+		return Answer{}, fmt.Errorf("refusing to query non-https gateway %s", gateway.URL)
+	}
+	resp, err := client.Get(queryURL)
+	if err != nil {
+		return Answer{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Answer{}, fmt.Errorf("gateway answered with status %s", resp.Status)
+	}
+	bz, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxAnswerSize))
+	if err != nil {
+		return Answer{}, err
+	}
+
+	var signed SignedDocument
+	if err := cdc.UnmarshalJSON(bz, &signed); err != nil {
+		return Answer{}, fmt.Errorf("invalid gateway answer: %s", err)
+	}
+	if signed.Document.Name != name {
+		return Answer{}, fmt.Errorf("gateway answered for %s instead of %s", signed.Document.Name, name)
+	}
+	switch err := Verify(signed, gateway.Signer, now); err {
+	case nil:
+	case ErrNotOwner:
+		return Answer{}, ErrNotGatewaySigner
+	default:
+		return Answer{}, err
+	}
+	return Answer{
+		Name:     name,
+		Gateway:  gateway,
+		Records:  signed.Document.Records,
+		Sequence: signed.Document.Sequence,
+		Expires:  signed.Document.Expires,
+	}, nil
+}
@@ -0,0 +1,166 @@
+// Package offchain signs and verifies record documents published outside the chain.
+// A document carries the records of a name together with a sequence and an expiry
+// and is signed with the key of the owner of the name, so that anyone can check it
+// against the owner recorded on chain without a transaction for every change.
+// Names redirected to an off-chain gateway are answered with documents in the same
+// format, signed by the signer of the gateway recorded on chain.
+package offchain
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/jerryma0912/Cosmos-sdk-tutorial/x/nameservice/types"
+)
+
+// Verification failures
+var (
+	ErrNotOwner         = errors.New("document is not signed by the owner of the name")
+	ErrInvalidSignature = errors.New("invalid signature")
+	ErrExpired          = errors.New("document has expired")
+	ErrNameExpired      = errors.New("name has expired")
+	ErrNoOwner          = errors.New("name has no owner")
+
+	// ErrVerificationFailed is returned by the verify command for invalid documents
+	ErrVerificationFailed = errors.New("verification failed")
+)
+
+// ModuleCdc encodes documents for signing
+var ModuleCdc = codec.New()
+
+func init() {
+	codec.RegisterCrypto(ModuleCdc)
+	// 注册后签名内容带有类型前缀，不会与交易的签名内容混淆
+	ModuleCdc.RegisterConcrete(Document{}, "nameservice/OffchainRecords", nil)
+}
+
+// Document is a set of records of a name published off chain. Verifiers should
+// prefer the valid document with the highest Sequence they have seen.
+type Document struct {
+	Name     string        `json:"name"`
+	Records  types.Records `json:"records"`
+	Sequence uint64        `json:"sequence"`
+	Expires  time.Time     `json:"expires"`
+}
+
+// ValidateBasic runs stateless checks on the document
+func (doc Document) ValidateBasic() error {
+	if err := types.ValidateName(doc.Name); err != nil {
+		return err
+	}
+	if len(doc.Records) > types.MaxRecordsPerName {
+		return fmt.Errorf("document cannot hold more than %d records", types.MaxRecordsPerName)
+	}
+	for _, r := range doc.Records {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+	}
+	if doc.Expires.IsZero() {
+		return errors.New("document is missing its expiry")
+	}
+	return nil
+}
+
+// SignBytes - returns the canonical bytes of the document that are signed
+func (doc Document) SignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(doc))
+}
+
+// SignedDocument is a document together with the signature of the owner of its name
+type SignedDocument struct {
+	Document  Document      `json:"document"`
+	PubKey    crypto.PubKey `json:"pub_key"`
+	Signature []byte        `json:"signature"`
+}
+
+// Signer signs bytes, like the Sign method of a keybase
+type Signer func(msg []byte) ([]byte, crypto.PubKey, error)
+
+// Sign signs the document with signer
+func Sign(doc Document, signer Signer) (SignedDocument, error) {
+	if err := doc.ValidateBasic(); err != nil {
+		return SignedDocument{}, err
+	}
+	sig, pubKey, err := signer(doc.SignBytes())
+	if err != nil {
+		return SignedDocument{}, err
+	}
+	return SignedDocument{Document: doc, PubKey: pubKey, Signature: sig}, nil
+}
+
+// Verify checks that the document is well formed, signed by owner and not expired at now
+func Verify(signed SignedDocument, owner sdk.AccAddress, now time.Time) error {
+	if err := signed.Document.ValidateBasic(); err != nil {
+		return err
+	}
+	if signed.PubKey == nil || !bytes.Equal(signed.PubKey.Address(), owner) {
+		return ErrNotOwner
+	}
+	if !signed.PubKey.VerifyBytes(signed.Document.SignBytes(), signed.Signature) {
+		return ErrInvalidSignature
+	}
+	if !now.Before(signed.Document.Expires) {
+		return ErrExpired
+	}
+	return nil
+}
+
+// QueryFunc runs an ABCI query against a node, like context.CLIContext.QueryWithData
+type QueryFunc func(path string, data []byte) ([]byte, int64, error)
+
+// Result is the outcome of verifying a document against chain state
+type Result struct {
+	Name     string         `json:"name"`
+	Sequence uint64         `json:"sequence"`
+	Owner    sdk.AccAddress `json:"owner"`  // owner of the name on chain
+	Height   int64          `json:"height"` // height of the chain state the document was checked against
+	Valid    bool           `json:"valid"`
+	Error    string         `json:"error,omitempty"`
+}
+
+// implement fmt.Stringer
+func (r Result) String() string {
+	if !r.Valid {
+		return fmt.Sprintf("%s (sequence %d): invalid at height %d: %s", r.Name, r.Sequence, r.Height, r.Error)
+	}
+	return fmt.Sprintf("%s (sequence %d): valid, signed by owner %s at height %d", r.Name, r.Sequence, r.Owner, r.Height)
+}
+
+// VerifyWithChain verifies the document against the current owner of its name, queried
+// from the nameservice module mounted at queryRoute. Failed verifications are reported
+// in the result; the error is only set when the chain could not be queried.
+func VerifyWithChain(signed SignedDocument, query QueryFunc, queryRoute string, cdc *codec.Codec, now time.Time) (Result, error) {
+	name := signed.Document.Name
+	res := Result{Name: name, Sequence: signed.Document.Sequence}
+	bz, height, err := query(fmt.Sprintf("custom/%s/whois/%s", queryRoute, name), nil)
+	if err != nil {
+		return res, err
+	}
+	var whois types.Whois
+	if err := cdc.UnmarshalJSON(bz, &whois); err != nil {
+		return res, err
+	}
+	res.Owner, res.Height = whois.Owner, height
+
+	switch {
+	case whois.Owner.Empty():
+		err = ErrNoOwner
+	case whois.IsExpired(height):
+		err = ErrNameExpired
+	default:
+		err = Verify(signed, whois.Owner, now)
+	}
+	if err != nil {
+		res.Error = err.Error()
+		return res, nil
+	}
+	res.Valid = true
+	return res, nil
+}
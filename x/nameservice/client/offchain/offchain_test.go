@@ -0,0 +1,185 @@
+package offchain_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	"github.com/jerryma0912/Cosmos-sdk-tutorial/x/nameservice"
+	"github.com/jerryma0912/Cosmos-sdk-tutorial/x/nameservice/client/offchain"
+)
+
+func keySigner(key crypto.PrivKey) offchain.Signer {
+	return func(msg []byte) ([]byte, crypto.PubKey, error) {
+		sig, err := key.Sign(msg)
+		return sig, key.PubKey(), err
+	}
+}
+
+// chainWith returns a QueryFunc answering whois queries at the given height with whois
+func chainWith(t *testing.T, cdc *codec.Codec, whois nameservice.Whois, height int64) offchain.QueryFunc {
+	return func(path string, data []byte) ([]byte, int64, error) {
+		require.Equal(t, "custom/nameservice/whois/alice", path)
+		return cdc.MustMarshalJSON(whois), height, nil
+	}
+}
+
+func TestVerifyWithChain(t *testing.T) {
+	cdc := codec.New()
+	codec.RegisterCrypto(cdc)
+	nameservice.RegisterCodec(cdc)
+
+	ownerKey, otherKey := secp256k1.GenPrivKey(), secp256k1.GenPrivKey()
+	owner := sdk.AccAddress(ownerKey.PubKey().Address())
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	doc := offchain.Document{
+		Name:     "alice",
+		Records:  nameservice.Records{nameservice.NewRecord("A", "192.0.2.1", 60)},
+		Sequence: 1,
+		Expires:  now.Add(time.Hour),
+	}
+	price := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 1))
+	chain := chainWith(t, cdc, nameservice.Whois{Owner: owner, Price: price, Expires: 100}, 10)
+
+	signed, err := offchain.Sign(doc, keySigner(ownerKey))
+	require.NoError(t, err)
+
+	// the signed document survives a JSON round trip, as it is passed around as a file
+	var decoded offchain.SignedDocument
+	cdc.MustUnmarshalJSON(cdc.MustMarshalJSON(signed), &decoded)
+	res, err := offchain.VerifyWithChain(decoded, chain, nameservice.StoreKey, cdc, now)
+	require.NoError(t, err)
+	require.True(t, res.Valid, res.Error)
+	require.Equal(t, owner, res.Owner)
+	require.Equal(t, int64(10), res.Height)
+
+	// tampering with the records breaks the signature
+	tampered := signed
+	tampered.Document.Records = nameservice.Records{nameservice.NewRecord("A", "192.0.2.66", 60)}
+	res, err = offchain.VerifyWithChain(tampered, chain, nameservice.StoreKey, cdc, now)
+	require.NoError(t, err)
+	require.Equal(t, offchain.ErrInvalidSignature.Error(), res.Error)
+
+	// documents signed by anyone but the current owner are rejected
+	forged, err := offchain.Sign(doc, keySigner(otherKey))
+	require.NoError(t, err)
+	res, err = offchain.VerifyWithChain(forged, chain, nameservice.StoreKey, cdc, now)
+	require.NoError(t, err)
+	require.Equal(t, offchain.ErrNotOwner.Error(), res.Error)
+
+	res, err = offchain.VerifyWithChain(signed, chain, nameservice.StoreKey, cdc, now.Add(2*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, offchain.ErrExpired.Error(), res.Error)
+
+	expired := chainWith(t, cdc, nameservice.Whois{Owner: owner, Price: price, Expires: 100}, 101)
+	res, err = offchain.VerifyWithChain(signed, expired, nameservice.StoreKey, cdc, now)
+	require.NoError(t, err)
+	require.Equal(t, offchain.ErrNameExpired.Error(), res.Error)
+}
+
+func TestFetchAnswer(t *testing.T) {
+	cdc := codec.New()
+	codec.RegisterCrypto(cdc)
+	nameservice.RegisterCodec(cdc)
+
+	signerKey, otherKey := secp256k1.GenPrivKey(), secp256k1.GenPrivKey()
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	records := nameservice.Records{nameservice.NewRecord("A", "192.0.2.1", 60)}
+	doc := offchain.Document{Name: "alice", Records: records, Sequence: 1, Expires: now.Add(time.Hour)}
+
+	// 本地网关总是返回 answer
+	var answer offchain.SignedDocument
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/names/alice.json", r.URL.Path)
+		w.Write(cdc.MustMarshalJSON(answer))
+	}))
+	defer srv.Close()
+	gateway := nameservice.NewGateway(srv.URL+"/names/{name}.json", sdk.AccAddress(signerKey.PubKey().Address()))
+
+	var err error
+	answer, err = offchain.Sign(doc, keySigner(signerKey))
+	require.NoError(t, err)
+	res, err := offchain.FetchAnswer(srv.Client(), gateway, "alice", cdc, now)
+	require.NoError(t, err)
+	require.Equal(t, records, res.Records)
+	require.Equal(t, uint64(1), res.Sequence)
+
+	_, err = offchain.FetchAnswer(srv.Client(), gateway, "alice", cdc, now.Add(2*time.Hour))
+	require.Equal(t, offchain.ErrExpired, err)
+
+	// answers signed by anyone but the signer of the gateway are rejected
+	answer, err = offchain.Sign(doc, keySigner(otherKey))
+	require.NoError(t, err)
+	_, err = offchain.FetchAnswer(srv.Client(), gateway, "alice", cdc, now)
+	require.Equal(t, offchain.ErrNotGatewaySigner, err)
+
+	// so are answers for another name
+	bob := doc
+	bob.Name = "bob"
+	answer, err = offchain.Sign(bob, keySigner(signerKey))
+	require.NoError(t, err)
+	_, err = offchain.FetchAnswer(srv.Client(), gateway, "alice", cdc, now)
+	require.Error(t, err)
+
+	// the default client does not reach local services, nor plain http gateways
+	_, err = offchain.FetchAnswer(offchain.DefaultClient, gateway, "alice", cdc, now)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "non-public address")
+	plain := nameservice.NewGateway("http://gateway.example/{name}.json", gateway.Signer)
+	_, err = offchain.FetchAnswer(srv.Client(), plain, "alice", cdc, now)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "non-https")
+}
+
+func TestDefaultClientRedirects(t *testing.T) {
+	// 网关重定向到非 https 或内网地址时，客户端在连接前拒绝
+	for _, target := range []string{"http://example.com/", "https://127.0.0.1/", "https://10.0.0.1/", "https://[::1]/", "https://169.254.169.254/"} {
+		srv := httptest.NewTLSServer(http.RedirectHandler(target, http.StatusFound))
+
+		// the test gateway itself listens on loopback, so only the redirect target is
+		// dialed like the default client does
+		client := srv.Client()
+		client.CheckRedirect = offchain.DefaultClient.CheckRedirect
+		transport := client.Transport.(*http.Transport)
+		dial := offchain.DefaultClient.Transport.(*http.Transport).DialContext
+		transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			if addr == srv.Listener.Addr().String() {
+				return (&net.Dialer{}).DialContext(ctx, network, addr)
+			}
+			return dial(ctx, network, addr)
+		}
+
+		_, err := client.Get(srv.URL)
+		require.Error(t, err, target)
+		require.Regexp(t, "non-https|non-public", err.Error())
+		srv.Close()
+	}
+}
+
+func TestIsPublicIP(t *testing.T) {
+	for ip, public := range map[string]bool{
+		"93.184.216.34":    true,
+		"2606:2800:220::1": true,
+		"127.0.0.1":        false,
+		"10.1.2.3":         false,
+		"172.20.0.1":       false,
+		"192.168.1.1":      false,
+		"169.254.169.254":  false,
+		"::1":              false,
+		"fe80::1":          false,
+		"fd00::1":          false,
+		"::ffff:127.0.0.1": false,
+	} {
+		require.Equal(t, public, offchain.IsPublicIP(net.ParseIP(ip)), ip)
+	}
+}
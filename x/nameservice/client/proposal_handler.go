@@ -0,0 +1,10 @@
+package client
+
+import (
+	govclient "github.com/cosmos/cosmos-sdk/x/gov/client"
+	"github.com/jerryma0912/Cosmos-sdk-tutorial/x/nameservice/client/cli"
+	"github.com/jerryma0912/Cosmos-sdk-tutorial/x/nameservice/client/rest"
+)
+
+// ProposalHandler is the CreateTLDProposal handler of the gov module's CLI and REST clients
+var ProposalHandler = govclient.NewProposalHandler(cli.GetCmdSubmitCreateTLDProposal, rest.ProposalRESTHandler)
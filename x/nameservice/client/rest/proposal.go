@@ -0,0 +1,84 @@
+package rest
+
+// 创建 TLD 的治理提案，挂载在 POST /gov/proposals/create_tld
+
+import (
+	"net/http"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/rest"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	govrest "github.com/cosmos/cosmos-sdk/x/gov/client/rest"
+	"github.com/jerryma0912/Cosmos-sdk-tutorial/x/nameservice/types"
+)
+
+type createTLDProposalReq struct {
+	BaseReq       rest.BaseReq   `json:"base_req"`
+	Title         string         `json:"title"`
+	Description   string         `json:"description"`
+	TLD           string         `json:"tld"`
+	Registrar     string         `json:"registrar"`
+	MinPrice      string         `json:"min_price"`
+	AllowedDenoms []string       `json:"allowed_denoms"` // 为空时使用最低价格的币种
+	Mode          string         `json:"mode"`
+	Proposer      sdk.AccAddress `json:"proposer"`
+	Deposit       sdk.Coins      `json:"deposit"`
+}
+
+// ProposalRESTHandler returns the REST handler of CreateTLDProposal for the gov module
+func ProposalRESTHandler(cliCtx context.CLIContext) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "create_tld",
+		Handler:  createTLDProposalHandler(cliCtx),
+	}
+}
+
+func createTLDProposalHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req createTLDProposalReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		name, ok := normalizeName(w, req.TLD)
+		if !ok {
+			return
+		}
+
+		registrar, err := sdk.AccAddressFromBech32(req.Registrar)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		minPrice, err := sdk.ParseCoins(req.MinPrice)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		denoms := req.AllowedDenoms
+		if len(denoms) == 0 {
+			for _, coin := range minPrice {
+				denoms = append(denoms, coin.Denom)
+			}
+		}
+
+		content := types.NewCreateTLDProposal(req.Title, req.Description, types.NewTLD(name, registrar, minPrice, denoms, req.Mode))
+
+		msg := gov.NewMsgSubmitProposal(content, req.Deposit, req.Proposer)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
@@ -7,8 +7,13 @@ package rest
 import (
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/jerryma0912/Cosmos-sdk-tutorial/x/nameservice/client/dns"
+	"github.com/jerryma0912/Cosmos-sdk-tutorial/x/nameservice/client/offchain"
 	"github.com/jerryma0912/Cosmos-sdk-tutorial/x/nameservice/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -16,10 +21,14 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
 
 	"github.com/gorilla/mux"
+	"github.com/spf13/viper"
 )
 
 const (
-	restName = "name"
+	restName     = "name"
+	restAddress  = "address"
+	restCoinType = "coinType"
+	restTextKey  = "key"
 )
 
 //首先在`RegisterRoutes`函数中为模块定义REST客户端接口。路由都以模块名称开头，以防止命名空间与其他模块的路径冲突：
@@ -30,6 +39,48 @@ func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, storeName string)
 	r.HandleFunc(fmt.Sprintf("/%s/names", storeName), setNameHandler(cliCtx)).Methods("PUT")
 	r.HandleFunc(fmt.Sprintf("/%s/names/{%s}", storeName, restName), resolveNameHandler(cliCtx, storeName)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/%s/names/{%s}/whois", storeName, restName), whoIsHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/names/{%s}/renew", storeName, restName), renewNameHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/names/{%s}/transfer", storeName, restName), transferHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/names/{%s}/transfer", storeName, restName), transferNameHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/names/{%s}/transfer/accept", storeName, restName), acceptTransferHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/names/{%s}/transfer/cancel", storeName, restName), cancelTransferHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/names/{%s}/subdomains", storeName, restName), subdomainsHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/names/{%s}/subdomains", storeName, restName), createSubdomainHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/names/{%s}/fuses", storeName, restName), burnFusesHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/names/{%s}/alias", storeName, restName), setAliasHandler(cliCtx)).Methods("PUT")
+	r.HandleFunc(fmt.Sprintf("/%s/names/{%s}/records", storeName, restName), recordsHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/names/{%s}/records", storeName, restName), setRecordHandler(cliCtx)).Methods("PUT")
+	r.HandleFunc(fmt.Sprintf("/%s/names/{%s}/records", storeName, restName), deleteRecordHandler(cliCtx)).Methods("DELETE")
+	r.HandleFunc(fmt.Sprintf("/%s/names/{%s}/addresses", storeName, restName), addressesHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/names/{%s}/addresses/{%s}", storeName, restName, restCoinType), addressesHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/names/{%s}/addresses/{%s}", storeName, restName, restCoinType), setAddressHandler(cliCtx)).Methods("PUT")
+	r.HandleFunc(fmt.Sprintf("/%s/names/{%s}/texts", storeName, restName), textsHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/names/{%s}/texts/{%s}", storeName, restName, restTextKey), textsHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/names/{%s}/texts/{%s}", storeName, restName, restTextKey), setTextHandler(cliCtx)).Methods("PUT")
+	r.HandleFunc(fmt.Sprintf("/%s/names/{%s}/contenthash", storeName, restName), contentHashHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/names/{%s}/contenthash", storeName, restName), setContentHashHandler(cliCtx)).Methods("PUT")
+	r.HandleFunc(fmt.Sprintf("/%s/names/{%s}/gateway", storeName, restName), setGatewayHandler(cliCtx)).Methods("PUT")
+	r.HandleFunc(fmt.Sprintf("/%s/names/{%s}/verify", storeName, restName), verifyHandler(cliCtx, storeName)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/owners/{%s}/names", storeName, restAddress), namesOfHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/reverse/{%s}", storeName, restAddress), reverseHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/reverse/{%s}", storeName, restAddress), setPrimaryNameHandler(cliCtx)).Methods("PUT")
+	r.HandleFunc(fmt.Sprintf("/%s/params", storeName), paramsHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/tlds", storeName), tldsHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/tlds/{%s}", storeName, restName), tldHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/tlds/{%s}", storeName, restName), setTLDHandler(cliCtx)).Methods("PUT")
+	r.HandleFunc(fmt.Sprintf("/%s/search", storeName), searchHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/available", storeName), availableHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/commitments", storeName), commitNameHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/auctions/{%s}", storeName, restName), auctionHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/auctions/{%s}/bids", storeName, restName), commitBidHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/auctions/{%s}/reveals", storeName, restName), revealBidHandler(cliCtx)).Methods("POST")
+
+	// DNS-over-HTTPS (RFC 8484 and JSON) answered from nameservice state
+	zone := viper.GetString(dns.FlagDNSZone)
+	if zone == "" {
+		zone = dns.DefaultZone
+	}
+	r.Handle("/dns-query", dns.NewNodeServer(cliCtx, zone, dns.DefaultCacheTTL, storeName)).Methods("GET", "POST")
 }
 
 // --------------------------------------------------------------------------------------
@@ -49,6 +100,7 @@ type buyNameReq struct {
 	Name    string       `json:"name"`
 	Amount  string       `json:"amount"`
 	Buyer   string       `json:"buyer"`
+	Salt    string       `json:"salt"`
 }
 
 func buyNameHandler(cliCtx context.CLIContext) http.HandlerFunc {
@@ -78,7 +130,12 @@ func buyNameHandler(cliCtx context.CLIContext) http.HandlerFunc {
 		}
 
 		// create the message
-		msg := types.NewMsgBuyName(req.Name, coins, addr)
+		name, ok := normalizeName(w, req.Name)
+		if !ok {
+			return
+		}
+
+		msg := types.NewMsgBuyName(name, coins, addr, req.Salt)
 		err = msg.ValidateBasic()
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
@@ -116,7 +173,12 @@ func setNameHandler(cliCtx context.CLIContext) http.HandlerFunc {
 		}
 
 		// create the message
-		msg := types.NewMsgSetName(req.Name, req.Value, addr)
+		name, ok := normalizeName(w, req.Name)
+		if !ok {
+			return
+		}
+
+		msg := types.NewMsgSetName(name, req.Value, addr)
 		err = msg.ValidateBasic()
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
@@ -127,49 +189,1289 @@ func setNameHandler(cliCtx context.CLIContext) http.HandlerFunc {
 	}
 }
 
-//--------------------------------------------------------------------------------------
-// Query Handlers
-//
-//接下来，是时候定义上面提到的处理程序了。
-//- 请注意，我们使用相同的`cliCtx.QueryWithData`函数来获取数据
-//- 这些函数与相应的CLI功能几乎相同
-func resolveNameHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+type renewNameReq struct {
+	BaseReq rest.BaseReq `json:"base_req"`
+	Owner   string       `json:"owner"`
+}
+
+func renewNameHandler(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
-		paramType := vars[restName]
+		name, ok := normalizeName(w, vars[restName])
+		if !ok {
+			return
+		}
 
-		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/resolve/%s", storeName, paramType), nil)
+		var req renewNameReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		addr, err := sdk.AccAddressFromBech32(req.Owner)
 		if err != nil {
-			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		rest.PostProcessResponse(w, cliCtx, res)
+		// create the message
+		msg := types.NewMsgRenewName(name, addr)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
 	}
 }
 
-func whoIsHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+type transferNameReq struct {
+	BaseReq       rest.BaseReq `json:"base_req"`
+	Owner         string       `json:"owner"`
+	Recipient     string       `json:"recipient"`
+	RequireAccept bool         `json:"require_accept"`
+}
+
+func transferNameHandler(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
-		paramType := vars[restName]
+		name, ok := normalizeName(w, vars[restName])
+		if !ok {
+			return
+		}
 
-		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/whois/%s", storeName, paramType), nil)
+		var req transferNameReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		owner, err := sdk.AccAddressFromBech32(req.Owner)
 		if err != nil {
-			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		rest.PostProcessResponse(w, cliCtx, res)
+		recipient, err := sdk.AccAddressFromBech32(req.Recipient)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// create the message
+		msg := types.NewMsgTransferName(name, owner, recipient, req.RequireAccept)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
 	}
 }
 
-func namesHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+type createSubdomainReq struct {
+	BaseReq rest.BaseReq `json:"base_req"`
+	Label   string       `json:"label"`   // 子域名的标签，如 api.acme 中的 api
+	Owner   string       `json:"owner"`   // 子域名的所有者，为空时为创建者自己
+	Creator string       `json:"creator"` // 父域名的所有者
+}
+
+// createSubdomainHandler serves POST /nameservice/names/{name}/subdomains
+func createSubdomainHandler(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/names", storeName), nil)
+		vars := mux.Vars(r)
+		parent, ok := normalizeName(w, vars[restName])
+		if !ok {
+			return
+		}
+
+		var req createSubdomainReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		creator, err := sdk.AccAddressFromBech32(req.Creator)
 		if err != nil {
-			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
 		}
-		rest.PostProcessResponse(w, cliCtx, res)
+
+		owner := creator
+		if req.Owner != "" {
+			owner, err = sdk.AccAddressFromBech32(req.Owner)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		// create the message
+		name, ok := normalizeName(w, types.SubdomainName(req.Label, parent))
+		if !ok {
+			return
+		}
+
+		msg := types.NewMsgCreateSubdomain(name, owner, creator)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type setAliasReq struct {
+	BaseReq rest.BaseReq `json:"base_req"`
+	Alias   string       `json:"alias"` // 为空时取消别名
+	Owner   string       `json:"owner"`
+}
+
+// setAliasHandler serves PUT /nameservice/names/{name}/alias
+func setAliasHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		name, ok := normalizeName(w, vars[restName])
+		if !ok {
+			return
+		}
+
+		var req setAliasReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		owner, err := sdk.AccAddressFromBech32(req.Owner)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// create the message
+		alias := req.Alias
+		if alias != "" {
+			if alias, ok = normalizeName(w, alias); !ok {
+				return
+			}
+		}
+
+		msg := types.NewMsgSetAlias(name, alias, owner)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type setAddressReq struct {
+	BaseReq rest.BaseReq `json:"base_req"`
+	Address string       `json:"address"` // 为空时删除该币种的地址
+	Owner   string       `json:"owner"`
+}
+
+// setAddressHandler serves PUT /nameservice/names/{name}/addresses/{coinType}
+func setAddressHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		name, ok := normalizeName(w, vars[restName])
+		if !ok {
+			return
+		}
+
+		coinType, err := strconv.ParseUint(vars[restCoinType], 10, 32)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("invalid coin type %s", vars[restCoinType]))
+			return
+		}
+
+		var req setAddressReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		owner, err := sdk.AccAddressFromBech32(req.Owner)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// create the message
+		msg := types.NewMsgSetAddress(name, uint32(coinType), req.Address, owner)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type setTextReq struct {
+	BaseReq rest.BaseReq `json:"base_req"`
+	Value   string       `json:"value"` // 为空时删除该文本记录
+	Owner   string       `json:"owner"`
+}
+
+// setTextHandler serves PUT /nameservice/names/{name}/texts/{key}
+func setTextHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		name, ok := normalizeName(w, vars[restName])
+		if !ok {
+			return
+		}
+
+		var req setTextReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		owner, err := sdk.AccAddressFromBech32(req.Owner)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// create the message
+		msg := types.NewMsgSetText(name, vars[restTextKey], req.Value, owner)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type setContentHashReq struct {
+	BaseReq     rest.BaseReq `json:"base_req"`
+	ContentHash string       `json:"content_hash"` // URI 或十六进制，为空时删除内容哈希
+	Owner       string       `json:"owner"`
+}
+
+// setContentHashHandler serves PUT /nameservice/names/{name}/contenthash
+func setContentHashHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		name, ok := normalizeName(w, vars[restName])
+		if !ok {
+			return
+		}
+
+		var req setContentHashReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		owner, err := sdk.AccAddressFromBech32(req.Owner)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var contentHash types.ContentHash
+		if req.ContentHash != "" {
+			contentHash, err = types.ParseContentHash(req.ContentHash)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		// create the message
+		msg := types.NewMsgSetContentHash(name, contentHash, owner)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type setGatewayReq struct {
+	BaseReq rest.BaseReq `json:"base_req"`
+	URL     string       `json:"url"`    // 为空时取消网关
+	Signer  string       `json:"signer"` // 网关应答的签名者
+	Owner   string       `json:"owner"`
+}
+
+// setGatewayHandler serves PUT /nameservice/names/{name}/gateway
+func setGatewayHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		name, ok := normalizeName(w, vars[restName])
+		if !ok {
+			return
+		}
+
+		var req setGatewayReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		owner, err := sdk.AccAddressFromBech32(req.Owner)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var signer sdk.AccAddress
+		if req.Signer != "" {
+			signer, err = sdk.AccAddressFromBech32(req.Signer)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		// create the message
+		msg := types.NewMsgSetGateway(name, req.URL, signer, owner)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type burnFusesReq struct {
+	BaseReq rest.BaseReq `json:"base_req"`
+	Fuses   []string     `json:"fuses"`  // 要烧断的权限位，如 cannot-reclaim
+	Signer  string       `json:"signer"` // 父域名的所有者，烧断 cannot-reclaim 后为子域名的所有者
+}
+
+// burnFusesHandler serves POST /nameservice/names/{name}/fuses
+func burnFusesHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		name, ok := normalizeName(w, vars[restName])
+		if !ok {
+			return
+		}
+
+		var req burnFusesReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		signer, err := sdk.AccAddressFromBech32(req.Signer)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		fuses, err := types.ParseFuses(req.Fuses)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// create the message
+		msg := types.NewMsgBurnFuses(name, fuses, signer)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type acceptTransferReq struct {
+	BaseReq   rest.BaseReq `json:"base_req"`
+	Recipient string       `json:"recipient"`
+}
+
+func acceptTransferHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		name, ok := normalizeName(w, vars[restName])
+		if !ok {
+			return
+		}
+
+		var req acceptTransferReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		addr, err := sdk.AccAddressFromBech32(req.Recipient)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// create the message
+		msg := types.NewMsgAcceptTransfer(name, addr)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type cancelTransferReq struct {
+	BaseReq rest.BaseReq `json:"base_req"`
+	Owner   string       `json:"owner"`
+}
+
+func cancelTransferHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		name, ok := normalizeName(w, vars[restName])
+		if !ok {
+			return
+		}
+
+		var req cancelTransferReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		addr, err := sdk.AccAddressFromBech32(req.Owner)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// create the message
+		msg := types.NewMsgCancelTransfer(name, addr)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type setRecordReq struct {
+	BaseReq rest.BaseReq `json:"base_req"`
+	Type    string       `json:"type"`
+	Value   string       `json:"value"`
+	TTL     uint32       `json:"ttl"`
+	Owner   string       `json:"owner"`
+}
+
+func setRecordHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		name, ok := normalizeName(w, vars[restName])
+		if !ok {
+			return
+		}
+
+		var req setRecordReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		addr, err := sdk.AccAddressFromBech32(req.Owner)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// create the message
+		msg := types.NewMsgSetRecord(name, types.NewRecord(req.Type, req.Value, req.TTL), addr)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type deleteRecordReq struct {
+	BaseReq rest.BaseReq `json:"base_req"`
+	Type    string       `json:"type"`
+	Value   string       `json:"value"` // all records of the type are deleted when empty
+	Owner   string       `json:"owner"`
+}
+
+func deleteRecordHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		name, ok := normalizeName(w, vars[restName])
+		if !ok {
+			return
+		}
+
+		var req deleteRecordReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		addr, err := sdk.AccAddressFromBech32(req.Owner)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// create the message
+		msg := types.NewMsgDeleteRecord(name, req.Type, req.Value, addr)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+// The address in the path is the owner; an empty name clears its primary name
+type setPrimaryNameReq struct {
+	BaseReq rest.BaseReq `json:"base_req"`
+	Name    string       `json:"name"`
+}
+
+func setPrimaryNameHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		var req setPrimaryNameReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		addr, err := sdk.AccAddressFromBech32(vars[restAddress])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// create the message
+		// 空域名表示清除主域名
+		name := ""
+		if req.Name != "" {
+			normalized, ok := normalizeName(w, req.Name)
+			if !ok {
+				return
+			}
+			name = normalized
+		}
+
+		msg := types.NewMsgSetPrimaryName(name, addr)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+// The name and salt are hashed here, so only the commitment ends up in the generated transaction
+type commitNameReq struct {
+	BaseReq   rest.BaseReq `json:"base_req"`
+	Name      string       `json:"name"`
+	Salt      string       `json:"salt"`
+	Committer string       `json:"committer"`
+}
+
+func commitNameHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req commitNameReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		addr, err := sdk.AccAddressFromBech32(req.Committer)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// create the message
+		name, ok := normalizeName(w, req.Name)
+		if !ok {
+			return
+		}
+
+		if err := types.ValidateSalt(req.Salt); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgCommitName(types.GetNameCommitment(name, addr, req.Salt), addr)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+// The bid and salt are hashed here, so only the sealed hash ends up in the generated transaction
+type commitBidReq struct {
+	BaseReq rest.BaseReq `json:"base_req"`
+	Bid     string       `json:"bid"`
+	Salt    string       `json:"salt"`
+	Deposit string       `json:"deposit"`
+	Bidder  string       `json:"bidder"`
+}
+
+func commitBidHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		name, ok := normalizeName(w, vars[restName])
+		if !ok {
+			return
+		}
+
+		var req commitBidReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		addr, err := sdk.AccAddressFromBech32(req.Bidder)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		bid, err := sdk.ParseCoin(req.Bid)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		deposit, err := sdk.ParseCoin(req.Deposit)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// create the message
+		msg := types.NewMsgCommitBid(name, types.GetBidHash(name, addr, bid, req.Salt), deposit, addr)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type revealBidReq struct {
+	BaseReq rest.BaseReq `json:"base_req"`
+	Bid     string       `json:"bid"`
+	Salt    string       `json:"salt"`
+	Bidder  string       `json:"bidder"`
+}
+
+func revealBidHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		name, ok := normalizeName(w, vars[restName])
+		if !ok {
+			return
+		}
+
+		var req revealBidReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		addr, err := sdk.AccAddressFromBech32(req.Bidder)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		bid, err := sdk.ParseCoin(req.Bid)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// create the message
+		msg := types.NewMsgRevealBid(name, bid, req.Salt, addr)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+//--------------------------------------------------------------------------------------
+// Query Handlers
+//
+//接下来，是时候定义上面提到的处理程序了。
+//- 请注意，我们使用相同的`cliCtx.QueryWithData`函数来获取数据
+//- 这些函数与相应的CLI功能几乎相同
+func resolveNameHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		paramType, ok := normalizeName(w, vars[restName])
+		if !ok {
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/resolve/%s", storeName, paramType), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		// 域名由链下网关解析且 rest-server 开启了 --fetch-gateways 时，返回经过验证的网关应答，
+		// 否则返回链上的网关，由客户端自行查询
+		var out types.QueryResResolve
+		if err := cliCtx.Codec.UnmarshalJSON(res, &out); err == nil && out.Gateway != nil && viper.GetBool(offchain.FlagFetchGateways) {
+			answer, err := offchain.FetchAnswer(offchain.DefaultClient, *out.Gateway, paramType, cliCtx.Codec, time.Now())
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadGateway, err.Error())
+				return
+			}
+			rest.PostProcessResponse(w, cliCtx, answer)
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func whoIsHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		paramType, ok := normalizeName(w, vars[restName])
+		if !ok {
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/whois/%s", storeName, paramType), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// subdomainsHandler serves GET /nameservice/names/{name}/subdomains
+func subdomainsHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		paramType, ok := normalizeName(w, vars[restName])
+		if !ok {
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/whois/%s/children", storeName, paramType), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func namesHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, page, limit, err := rest.ParseHTTPArgsWithLimit(r, types.DefaultNamesLimit)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		params := types.NewQueryNamesParams(page, limit, r.FormValue("start"), r.FormValue("parent"))
+		bz, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/names", storeName), bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// recordsHandler serves GET /nameservice/names/{name}/records?type=
+func recordsHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		paramType, ok := normalizeName(w, vars[restName])
+		if !ok {
+			return
+		}
+
+		route := fmt.Sprintf("custom/%s/records/%s", storeName, paramType)
+		if recordType := r.FormValue("type"); recordType != "" {
+			route = fmt.Sprintf("%s/%s", route, recordType)
+		}
+
+		res, _, err := cliCtx.QueryWithData(route, nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// addressesHandler serves GET /nameservice/names/{name}/addresses and
+// GET /nameservice/names/{name}/addresses/{coinType}
+func addressesHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		paramType, ok := normalizeName(w, vars[restName])
+		if !ok {
+			return
+		}
+
+		route := fmt.Sprintf("custom/%s/addr/%s", storeName, paramType)
+		if coinType := vars[restCoinType]; coinType != "" {
+			route = fmt.Sprintf("%s/%s", route, coinType)
+		}
+
+		res, _, err := cliCtx.QueryWithData(route, nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// textsHandler serves GET /nameservice/names/{name}/texts and
+// GET /nameservice/names/{name}/texts/{key}
+func textsHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		paramType, ok := normalizeName(w, vars[restName])
+		if !ok {
+			return
+		}
+
+		route := fmt.Sprintf("custom/%s/text/%s", storeName, paramType)
+		if key := vars[restTextKey]; key != "" {
+			route = fmt.Sprintf("%s/%s", route, key)
+		}
+
+		res, _, err := cliCtx.QueryWithData(route, nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// contentHashHandler serves GET /nameservice/names/{name}/contenthash, returning
+// the content hash both as raw hex and as a URI
+func contentHashHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		paramType, ok := normalizeName(w, vars[restName])
+		if !ok {
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/contenthash/%s", storeName, paramType), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// verifyHandler serves POST /nameservice/names/{name}/verify, checking an owner-signed
+// off-chain records document against the current owner of the name
+func verifyHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		name, ok := normalizeName(w, vars[restName])
+		if !ok {
+			return
+		}
+
+		var signed offchain.SignedDocument
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &signed) {
+			return
+		}
+		if signed.Document.Name != name {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("document is for %s, not %s", signed.Document.Name, name))
+			return
+		}
+
+		res, err := offchain.VerifyWithChain(signed, cliCtx.QueryWithData, storeName, cliCtx.Codec, time.Now())
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func transferHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		paramType, ok := normalizeName(w, vars[restName])
+		if !ok {
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/transfer/%s", storeName, paramType), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func auctionHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		paramType, ok := normalizeName(w, vars[restName])
+		if !ok {
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/auction/%s", storeName, paramType), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func paramsHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/params", storeName), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// tldsHandler serves GET /nameservice/tlds
+func tldsHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/tlds", storeName), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// tldHandler serves GET /nameservice/tlds/{tld}
+func tldHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		paramType, ok := normalizeName(w, vars[restName])
+		if !ok {
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/tld/%s", storeName, paramType), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+type setTLDReq struct {
+	BaseReq       rest.BaseReq `json:"base_req"`
+	Registrar     string       `json:"registrar"`
+	MinPrice      string       `json:"min_price"`
+	AllowedDenoms []string     `json:"allowed_denoms"` // 为空时使用最低价格的币种
+	Mode          string       `json:"mode"`
+	Signer        string       `json:"signer"` // TLD 的注册商
+}
+
+// setTLDHandler serves PUT /nameservice/tlds/{tld}
+func setTLDHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		name, ok := normalizeName(w, vars[restName])
+		if !ok {
+			return
+		}
+
+		var req setTLDReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		signer, err := sdk.AccAddressFromBech32(req.Signer)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		registrar, err := sdk.AccAddressFromBech32(req.Registrar)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		minPrice, err := sdk.ParseCoins(req.MinPrice)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		denoms := req.AllowedDenoms
+		if len(denoms) == 0 {
+			for _, coin := range minPrice {
+				denoms = append(denoms, coin.Denom)
+			}
+		}
+
+		// create the message
+		msg := types.NewMsgSetTLD(types.NewTLD(name, registrar, minPrice, denoms, req.Mode), signer)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+func namesOfHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		paramType := vars[restAddress]
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/owner/%s", storeName, paramType), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func reverseHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		paramType := vars[restAddress]
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/reverse/%s", storeName, paramType), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// searchHandler serves GET /nameservice/search?prefix=&contains=&start=&limit=
+func searchHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, _, limit, err := rest.ParseHTTPArgsWithLimit(r, types.DefaultNamesLimit)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		params := types.NewQuerySearchParams(r.FormValue("prefix"), r.FormValue("contains"), r.FormValue("start"), limit)
+		bz, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/search", storeName), bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// availableHandler serves GET /nameservice/available?names=alice,bob
+func availableHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		names := []string{}
+		for _, name := range strings.Split(r.FormValue("names"), ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+
+		bz, err := cliCtx.Codec.MarshalJSON(types.NewQueryAvailabilityParams(names))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/available", storeName), bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// normalizeName - returns the canonical form of a name given in a request, see
+// types.NormalizeName, writing an error response if the name is invalid
+func normalizeName(w http.ResponseWriter, name string) (string, bool) {
+	normalized, err := types.NormalizeName(name)
+	if err != nil {
+		rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return "", false
 	}
+	return normalized, true
 }
@@ -0,0 +1,60 @@
+package nameservice
+
+// 承诺-揭示注册：购买者先提交加盐的哈希值，等待若干区块后再用 MsgBuyName 公开域名，
+// 这样观察内存池的人无法在同一区块内抢先购买。
+import (
+	"github.com/jerryma0912/Cosmos-sdk-tutorial/x/nameservice/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GetCommitment - gets a commitment made by a committer, if any
+func (k Keeper) GetCommitment(ctx sdk.Context, committer sdk.AccAddress, hash []byte) (commitment Commitment, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetCommitmentKey(committer, hash))
+	if bz == nil {
+		return commitment, false
+	}
+	k.cdc.MustUnmarshalBinaryBare(bz, &commitment)
+	return commitment, true
+}
+
+// SetCommitment - stores a commitment and queues it for pruning once it becomes stale
+func (k Keeper) SetCommitment(ctx sdk.Context, commitment Commitment) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetCommitmentKey(commitment.Committer, commitment.Hash)
+	store.Set(key, k.cdc.MustMarshalBinaryBare(commitment))
+	store.Set(types.GetCommitmentQueueKey(commitment.Height, commitment.Committer, commitment.Hash), key)
+}
+
+// DeleteCommitment - removes a commitment together with its queue entry
+func (k Keeper) DeleteCommitment(ctx sdk.Context, commitment Commitment) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetCommitmentQueueKey(commitment.Height, commitment.Committer, commitment.Hash))
+	store.Delete(types.GetCommitmentKey(commitment.Committer, commitment.Hash))
+}
+
+// GetCommitmentsIterator - returns an iterator over all commitments
+func (k Keeper) GetCommitmentsIterator(ctx sdk.Context) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.CommitmentKeyPrefix)
+}
+
+// PruneCommitments - removes every commitment made strictly before the given height
+func (k Keeper) PruneCommitments(ctx sdk.Context, height int64) {
+	store := ctx.KVStore(k.storeKey)
+	if height < 0 {
+		height = 0
+	}
+	iterator := store.Iterator(types.CommitmentQueueKeyPrefix, types.GetCommitmentQueueHeightKey(height))
+	var stale [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		stale = append(stale, iterator.Key(), iterator.Value())
+	}
+	iterator.Close()
+
+	// 队列中的值就是承诺本身的存储键
+	for _, key := range stale {
+		store.Delete(key)
+	}
+}
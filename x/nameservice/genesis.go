@@ -7,50 +7,328 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 )
 
+// WhoisRecord pairs a Whois with the name it is stored under, since Whois itself
+// does not carry its name
+type WhoisRecord struct {
+	Name        string         `json:"name"`
+	Whois       Whois          `json:"whois"`
+	Records     Records        `json:"records"`      // typed records of the name, in addition to Whois.Value
+	Addresses   AddressRecords `json:"addresses"`    // addresses of the name on other chains, by SLIP-44 coin type
+	Texts       TextRecords    `json:"texts"`        // key/value text records making up the profile of the name
+	ContentHash ContentHash    `json:"content_hash"` // content published by the name, as a URI
+}
+
+// PrimaryName is the primary name an address resolves to in reverse lookups
+type PrimaryName struct {
+	Address sdk.AccAddress `json:"address"`
+	Name    string         `json:"name"`
+}
+
 type GenesisState struct {
-	WhoisRecords []Whois `json:"whois_records"`
+	Params           Params            `json:"params"`
+	WhoisRecords     []WhoisRecord     `json:"whois_records"`
+	Auctions         []Auction         `json:"auctions"`
+	Bids             []Bid             `json:"bids"`
+	Commitments      []Commitment      `json:"commitments"`
+	PendingTransfers []PendingTransfer `json:"pending_transfers"`
+	PrimaryNames     []PrimaryName     `json:"primary_names"`
+	TLDs             []TLD             `json:"tlds"`
 }
 
-func NewGenesisState(whoIsRecords []Whois) GenesisState {
-	return GenesisState{WhoisRecords: nil}
+func NewGenesisState(params Params, whoIsRecords []WhoisRecord) GenesisState {
+	return GenesisState{Params: params, WhoisRecords: whoIsRecords}
 }
 
 func ValidateGenesis(data GenesisState) error {
+	if err := data.Params.Validate(); err != nil {
+		return err
+	}
+	names := make(map[string]sdk.AccAddress)
+	for _, record := range data.WhoisRecords {
+		if record.Name == "" {
+			return fmt.Errorf("Invalid WhoisRecord: Owner: %s. Error: Missing Name", record.Whois.Owner)
+		}
+		if err := ValidateName(record.Name); err != nil {
+			return fmt.Errorf("Invalid WhoisRecord: Name: %s. Error: %s", record.Name, err)
+		}
+		if _, ok := names[record.Name]; ok {
+			return fmt.Errorf("Invalid WhoisRecord: Name: %s. Error: Duplicate Name", record.Name)
+		}
+		names[record.Name] = record.Whois.Owner
+		if record.Whois.Owner.Empty() {
+			return fmt.Errorf("Invalid WhoisRecord: Name: %s. Error: Missing Owner", record.Name)
+		}
+		if record.Whois.Price.Empty() || !record.Whois.Price.IsValid() {
+			return fmt.Errorf("Invalid WhoisRecord: Name: %s. Error: Invalid Price %s", record.Name, record.Whois.Price)
+		}
+		if record.Whois.Expires < 0 {
+			return fmt.Errorf("Invalid WhoisRecord: Name: %s. Error: Negative Expires", record.Name)
+		}
+		if record.Whois.Alias != "" {
+			if err := ValidateName(record.Whois.Alias); err != nil {
+				return fmt.Errorf("Invalid WhoisRecord: Name: %s. Error: Invalid Alias: %s", record.Name, err)
+			}
+		}
+		if !record.Whois.Gateway.Empty() {
+			if err := record.Whois.Gateway.Validate(); err != nil {
+				return fmt.Errorf("Invalid WhoisRecord: Name: %s. Error: %s", record.Name, err)
+			}
+		}
+		if !record.Whois.Fuses.IsValid() {
+			return fmt.Errorf("Invalid WhoisRecord: Name: %s. Error: Unknown Fuses", record.Name)
+		}
+		if record.Whois.Fuses != 0 && !IsSubdomain(record.Name) {
+			return fmt.Errorf("Invalid WhoisRecord: Name: %s. Error: Fuses are only allowed on subdomains", record.Name)
+		}
+		if len(record.Records) > MaxRecordsPerName {
+			return fmt.Errorf("Invalid WhoisRecord: Name: %s. Error: More than %d records", record.Name, MaxRecordsPerName)
+		}
+		for _, r := range record.Records {
+			if err := r.Validate(); err != nil {
+				return fmt.Errorf("Invalid WhoisRecord: Name: %s. Error: %s", record.Name, err)
+			}
+		}
+		if len(record.Addresses) > MaxAddressesPerName {
+			return fmt.Errorf("Invalid WhoisRecord: Name: %s. Error: More than %d addresses", record.Name, MaxAddressesPerName)
+		}
+		coinTypes := make(map[uint32]bool)
+		for _, a := range record.Addresses {
+			if coinTypes[a.CoinType] {
+				return fmt.Errorf("Invalid WhoisRecord: Name: %s. Error: Duplicate address for coin type %d", record.Name, a.CoinType)
+			}
+			coinTypes[a.CoinType] = true
+			if err := ValidateAddress(a.CoinType, a.Address); err != nil {
+				return fmt.Errorf("Invalid WhoisRecord: Name: %s. Error: %s", record.Name, err)
+			}
+		}
+		if len(record.ContentHash) > 0 {
+			if err := record.ContentHash.Validate(); err != nil {
+				return fmt.Errorf("Invalid WhoisRecord: Name: %s. Error: %s", record.Name, err)
+			}
+		}
+		if len(record.Texts) > MaxTextsPerName {
+			return fmt.Errorf("Invalid WhoisRecord: Name: %s. Error: More than %d texts", record.Name, MaxTextsPerName)
+		}
+		keys := make(map[string]bool)
+		for _, text := range record.Texts {
+			if keys[text.Key] {
+				return fmt.Errorf("Invalid WhoisRecord: Name: %s. Error: Duplicate text %s", record.Name, text.Key)
+			}
+			keys[text.Key] = true
+			if err := text.Validate(); err != nil {
+				return fmt.Errorf("Invalid WhoisRecord: Name: %s. Error: %s", record.Name, err)
+			}
+		}
+	}
+	tlds := make(map[string]bool)
+	for _, tld := range data.TLDs {
+		if err := tld.Validate(); err != nil {
+			return fmt.Errorf("Invalid TLD: Name: %s. Error: %s", tld.Name, err)
+		}
+		if tlds[tld.Name] {
+			return fmt.Errorf("Invalid TLD: Name: %s. Error: Duplicate TLD", tld.Name)
+		}
+		if _, ok := names[tld.Name]; ok {
+			return fmt.Errorf("Invalid TLD: Name: %s. Error: Name is already registered", tld.Name)
+		}
+		tlds[tld.Name] = true
+	}
+	// 子域名的父域名必须同样存在，或者是一个 TLD
 	for _, record := range data.WhoisRecords {
-		if record.Owner == nil {
-			return fmt.Errorf("Invalid WhoisRecord: Value: %s. Error: Missing Owner", record.Value)
+		if parent, ok := ParentName(record.Name); ok {
+			if _, ok := names[parent]; !ok && !tlds[parent] {
+				return fmt.Errorf("Invalid WhoisRecord: Name: %s. Error: Parent %s does not exist", record.Name, parent)
+			}
+		}
+	}
+	auctions := make(map[string]bool)
+	for _, auction := range data.Auctions {
+		if auction.Name == "" {
+			return fmt.Errorf("Invalid Auction: Error: Missing Name")
 		}
-		if record.Value == "" {
-			return fmt.Errorf("Invalid WhoisRecord: Owner: %s. Error: Missing Value", record.Owner)
+		if err := ValidateName(auction.Name); err != nil {
+			return fmt.Errorf("Invalid Auction: Name: %s. Error: %s", auction.Name, err)
 		}
-		if record.Price == nil {
-			return fmt.Errorf("Invalid WhoisRecord: Value: %s. Error: Missing Price", record.Value)
+		if auction.RevealEnd < auction.CommitEnd {
+			return fmt.Errorf("Invalid Auction: Name: %s. Error: Reveal window ends before commit window", auction.Name)
+		}
+		if auction.MinPrice.Denom != "" && !(sdk.Coins{auction.MinPrice}).IsValid() {
+			return fmt.Errorf("Invalid Auction: Name: %s. Error: Invalid Min Price %s", auction.Name, auction.MinPrice)
+		}
+		auctions[auction.Name] = true
+	}
+	for _, bid := range data.Bids {
+		if !auctions[bid.Name] {
+			return fmt.Errorf("Invalid Bid: Name: %s. Error: No running auction", bid.Name)
+		}
+		if bid.Bidder.Empty() {
+			return fmt.Errorf("Invalid Bid: Name: %s. Error: Missing Bidder", bid.Name)
+		}
+		if !(sdk.Coins{bid.Deposit}).IsValid() {
+			return fmt.Errorf("Invalid Bid: Name: %s. Error: Invalid Deposit %s", bid.Name, bid.Deposit)
+		}
+	}
+	for _, commitment := range data.Commitments {
+		if commitment.Committer.Empty() {
+			return fmt.Errorf("Invalid Commitment: Hash: %X. Error: Missing Committer", commitment.Hash)
+		}
+		if len(commitment.Hash) == 0 {
+			return fmt.Errorf("Invalid Commitment: Committer: %s. Error: Missing Hash", commitment.Committer)
+		}
+	}
+	for _, transfer := range data.PendingTransfers {
+		owner, ok := names[transfer.Name]
+		if !ok {
+			return fmt.Errorf("Invalid PendingTransfer: Name: %s. Error: Unknown Name", transfer.Name)
+		}
+		if !owner.Equals(transfer.Owner) {
+			return fmt.Errorf("Invalid PendingTransfer: Name: %s. Error: Owner does not own the name", transfer.Name)
+		}
+		if transfer.Recipient.Empty() {
+			return fmt.Errorf("Invalid PendingTransfer: Name: %s. Error: Missing Recipient", transfer.Name)
+		}
+	}
+	for _, primary := range data.PrimaryNames {
+		owner, ok := names[primary.Name]
+		if !ok || !owner.Equals(primary.Address) {
+			return fmt.Errorf("Invalid PrimaryName: Address: %s. Error: Name %s is not owned by the address", primary.Address, primary.Name)
 		}
 	}
 	return nil
 }
 
+// DefaultGenesisState returns a genesis state without names and without TLDs: every TLD
+// needs a registrar, so none is seeded. Until a CreateTLDProposal passes, names are only
+// registered in the root namespace under the module params.
 func DefaultGenesisState() GenesisState {
 	return GenesisState{
-		WhoisRecords: []Whois{},
+		Params:           DefaultParams(),
+		WhoisRecords:     []WhoisRecord{},
+		Auctions:         []Auction{},
+		Bids:             []Bid{},
+		Commitments:      []Commitment{},
+		PendingTransfers: []PendingTransfer{},
+		PrimaryNames:     []PrimaryName{},
+		TLDs:             []TLD{},
 	}
 }
 
 func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) []abci.ValidatorUpdate {
+	keeper.SetParams(ctx, data.Params)
+	for _, tld := range data.TLDs {
+		keeper.SetTLD(ctx, tld)
+	}
 	for _, record := range data.WhoisRecords {
-		keeper.SetWhois(ctx, record.Value, record)
+		// records from genesis files written before names expired get a fresh registration period
+		if record.Whois.Expires == 0 {
+			record.Whois.Expires = ctx.BlockHeight() + data.Params.RegistrationPeriod
+		}
+		// SetWhois also rebuilds the expiry queue and the owner index entries of the record
+		keeper.SetWhois(ctx, record.Name, record.Whois)
+		keeper.SetRecords(ctx, record.Name, record.Records)
+		for _, a := range record.Addresses {
+			keeper.SetAddress(ctx, record.Name, a.CoinType, a.Address)
+		}
+		for _, text := range record.Texts {
+			keeper.SetText(ctx, record.Name, text.Key, text.Value)
+		}
+		keeper.SetContentHash(ctx, record.Name, record.ContentHash)
+	}
+	for _, auction := range data.Auctions {
+		// auctions from genesis files written before auctions recorded their min price
+		// are settled against the current policy of their registry
+		if auction.MinPrice.Denom == "" {
+			auction.MinPrice = keeper.GetRegistry(ctx, auction.Name).AuctionMinPrice()
+		}
+		keeper.SetAuction(ctx, auction)
+	}
+	for _, bid := range data.Bids {
+		keeper.SetBid(ctx, bid)
+	}
+	for _, commitment := range data.Commitments {
+		keeper.SetCommitment(ctx, commitment)
+	}
+	for _, transfer := range data.PendingTransfers {
+		keeper.SetPendingTransfer(ctx, transfer)
+	}
+	for _, primary := range data.PrimaryNames {
+		keeper.SetPrimaryName(ctx, primary.Address, primary.Name)
 	}
 	return []abci.ValidatorUpdate{}
 }
 
 func ExportGenesis(ctx sdk.Context, k Keeper) GenesisState {
-	var records []Whois
+	var records []WhoisRecord
 	iterator := k.GetNamesIterator(ctx)
+	defer iterator.Close()
 	for ; iterator.Valid(); iterator.Next() {
-		name := string(iterator.Key())
 		var whois Whois
-		whois = k.GetWhois(ctx, name)
-		records = append(records, whois)
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &whois)
+		name := string(iterator.Key())
+		records = append(records, WhoisRecord{
+			Name:        name,
+			Whois:       whois,
+			Records:     k.GetRecords(ctx, name),
+			Addresses:   k.GetAddresses(ctx, name),
+			Texts:       k.GetTexts(ctx, name),
+			ContentHash: k.GetContentHash(ctx, name),
+		})
+	}
+
+	var auctions []Auction
+	var bids []Bid
+	auctionIterator := k.GetAuctionsIterator(ctx)
+	defer auctionIterator.Close()
+	for ; auctionIterator.Valid(); auctionIterator.Next() {
+		var auction Auction
+		k.cdc.MustUnmarshalBinaryBare(auctionIterator.Value(), &auction)
+		auctions = append(auctions, auction)
+		bids = append(bids, k.GetBids(ctx, auction.Name)...)
+	}
+
+	var commitments []Commitment
+	commitmentIterator := k.GetCommitmentsIterator(ctx)
+	defer commitmentIterator.Close()
+	for ; commitmentIterator.Valid(); commitmentIterator.Next() {
+		var commitment Commitment
+		k.cdc.MustUnmarshalBinaryBare(commitmentIterator.Value(), &commitment)
+		commitments = append(commitments, commitment)
+	}
+
+	var transfers []PendingTransfer
+	transferIterator := k.GetPendingTransfersIterator(ctx)
+	defer transferIterator.Close()
+	for ; transferIterator.Valid(); transferIterator.Next() {
+		var transfer PendingTransfer
+		k.cdc.MustUnmarshalBinaryBare(transferIterator.Value(), &transfer)
+		transfers = append(transfers, transfer)
+	}
+
+	var primaryNames []PrimaryName
+	primaryIterator := k.GetPrimaryNamesIterator(ctx)
+	defer primaryIterator.Close()
+	for ; primaryIterator.Valid(); primaryIterator.Next() {
+		primaryNames = append(primaryNames, PrimaryName{
+			Address: sdk.AccAddress(primaryIterator.Key()),
+			Name:    string(primaryIterator.Value()),
+		})
+	}
+	var tlds []TLD
+	tldIterator := k.GetTLDsIterator(ctx)
+	defer tldIterator.Close()
+	for ; tldIterator.Valid(); tldIterator.Next() {
+		var tld TLD
+		k.cdc.MustUnmarshalBinaryBare(tldIterator.Value(), &tld)
+		tlds = append(tlds, tld)
+	}
+	return GenesisState{
+		Params:           k.GetParams(ctx),
+		WhoisRecords:     records,
+		Auctions:         auctions,
+		Bids:             bids,
+		Commitments:      commitments,
+		PendingTransfers: transfers,
+		PrimaryNames:     primaryNames,
+		TLDs:             tlds,
 	}
-	return GenesisState{WhoisRecords: records}
 }
@@ -0,0 +1,97 @@
+package nameservice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	addr1 = sdk.AccAddress([]byte("addr1_______________"))
+	addr2 = sdk.AccAddress([]byte("addr2_______________"))
+)
+
+func TestGenesisRoundTrip(t *testing.T) {
+	ctx, keeper := CreateTestInput(t)
+	price := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 5))
+
+	// the two names share a value, which used to collapse them into a single record
+	keeper.SetWhois(ctx, "alice", Whois{Value: "1.2.3.4", Owner: addr1, Price: price, Expires: 100})
+	keeper.SetWhois(ctx, "bob", Whois{Value: "1.2.3.4", Owner: addr2, Price: price, Expires: 200})
+	keeper.SetWhois(ctx, "carol", Whois{Owner: addr1, Price: price, Expires: 300})
+	keeper.SetWhois(ctx, "api.alice", Whois{Owner: addr2, Price: price, Expires: 100, Fuses: FuseCannotReclaim | FuseCannotTransfer})
+	keeper.SetRecords(ctx, "alice", Records{NewRecord("A", "1.2.3.4", 0), NewRecord("MX", "10 mail.alice.", 60)})
+	keeper.SetAddress(ctx, "alice", 60, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	keeper.SetText(ctx, "alice", "com.github", "alice")
+	contentHash, err := ParseContentHash("ipfs://QmRAQB6YaCyidP37UdDnjFY5vQuiBrcqdyoW1CuDgwxkD4")
+	require.NoError(t, err)
+	keeper.SetContentHash(ctx, "bob", contentHash)
+	keeper.SetAuction(ctx, NewAuction("dave", 1, 10, 10, price[0]))
+	keeper.SetBid(ctx, Bid{Name: "dave", Bidder: addr2, BidHash: GetBidHash("dave", addr2, price[0], "salt"), Deposit: price[0]})
+	keeper.SetCommitment(ctx, Commitment{Committer: addr1, Hash: GetNameCommitment("erin", addr1, "secretsalt"), Height: 1})
+	keeper.SetPendingTransfer(ctx, PendingTransfer{Name: "carol", Owner: addr1, Recipient: addr2, Height: 1})
+	keeper.SetPrimaryName(ctx, addr2, "bob")
+	keeper.SetTLD(ctx, NewTLD("dev", addr1, price, []string{"nametoken"}, RegistrationModeOpen))
+	keeper.SetWhois(ctx, "erin.dev", Whois{Owner: addr2, Price: price, Expires: 100})
+
+	exported := ExportGenesis(ctx, keeper)
+	require.NoError(t, ValidateGenesis(exported))
+	require.Len(t, exported.WhoisRecords, 5)
+
+	bz := ModuleCdc.MustMarshalJSON(exported)
+	var imported GenesisState
+	ModuleCdc.MustUnmarshalJSON(bz, &imported)
+
+	ctx2, keeper2 := CreateTestInput(t)
+	InitGenesis(ctx2, keeper2, imported)
+	require.Equal(t, exported, ExportGenesis(ctx2, keeper2))
+	require.Equal(t, addr2, keeper2.GetOwner(ctx2, "bob"))
+	require.True(t, keeper2.GetWhois(ctx2, "api.alice").Fuses.Has(FuseCannotTransfer))
+	require.Equal(t, []string{"api.alice"}, keeper2.GetChildren(ctx2, "alice"))
+	require.Equal(t, "dev", keeper2.GetRegistry(ctx2, "erin.dev").Name)
+	require.Equal(t, "1.2.3.4", keeper2.ResolveName(ctx2, "alice"))
+	require.Len(t, keeper2.ResolveRecords(ctx2, "alice", ""), 3)
+	require.Equal(t, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", keeper2.GetAddress(ctx2, "alice", 60))
+	require.Equal(t, "alice", keeper2.GetText(ctx2, "alice", "com.github"))
+	require.Equal(t, "ipfs://bafybeibj6lixxzqtsb45ysdjnupvqkufgdvzqbnvmhw2kf7cfkesy7r7d4", keeper2.GetContentHash(ctx2, "bob").String())
+}
+
+func TestValidateGenesis(t *testing.T) {
+	price := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 5))
+	record := WhoisRecord{Name: "alice", Whois: Whois{Owner: addr1, Price: price}}
+
+	tests := []struct {
+		name    string
+		records []WhoisRecord
+		valid   bool
+	}{
+		{"valid record", []WhoisRecord{record}, true},
+		{"duplicate name", []WhoisRecord{record, record}, false},
+		{"empty name", []WhoisRecord{{Whois: record.Whois}}, false},
+		{"subdomain", []WhoisRecord{record, {Name: "api.alice", Whois: record.Whois}}, true},
+		{"subdomain without parent", []WhoisRecord{{Name: "api.alice", Whois: record.Whois}}, false},
+		{"empty label", []WhoisRecord{{Name: "alice.", Whois: record.Whois}}, false},
+		{"punycode name", []WhoisRecord{{Name: "xn--bcher-kva", Whois: record.Whois}}, true},
+		{"upper case name", []WhoisRecord{{Name: "Alice", Whois: record.Whois}}, false},
+		{"unicode name", []WhoisRecord{{Name: "bücher", Whois: record.Whois}}, false},
+		{"whitespace in name", []WhoisRecord{{Name: "alice ", Whois: record.Whois}}, false},
+		{"fuses on subdomain", []WhoisRecord{record, {Name: "api.alice", Whois: Whois{Owner: addr1, Price: price, Fuses: FuseCannotReclaim}}}, true},
+		{"fuses on top-level name", []WhoisRecord{{Name: "alice", Whois: Whois{Owner: addr1, Price: price, Fuses: FuseCannotReclaim}}}, false},
+		{"missing owner", []WhoisRecord{{Name: "alice", Whois: Whois{Price: price}}}, false},
+		{"missing price", []WhoisRecord{{Name: "alice", Whois: Whois{Owner: addr1}}}, false},
+		{"invalid record", []WhoisRecord{{Name: "alice", Whois: record.Whois, Records: Records{NewRecord("A", "::1", 0)}}}, false},
+		{"invalid address", []WhoisRecord{{Name: "alice", Whois: record.Whois, Addresses: AddressRecords{{CoinType: 60, Address: "0x5AAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"}}}}, false},
+		{"invalid text", []WhoisRecord{{Name: "alice", Whois: record.Whois, Texts: TextRecords{{Key: "com github", Value: "alice"}}}}, false},
+		{"invalid coins", []WhoisRecord{{Name: "alice", Whois: Whois{Owner: addr1, Price: sdk.Coins{sdk.Coin{Denom: "nametoken", Amount: sdk.ZeroInt()}}}}}, false},
+	}
+	for _, tc := range tests {
+		err := ValidateGenesis(NewGenesisState(DefaultParams(), tc.records))
+		if tc.valid {
+			require.NoError(t, err, tc.name)
+		} else {
+			require.Error(t, err, tc.name)
+		}
+	}
+}
@@ -6,6 +6,7 @@ package nameservice
 // test
 import (
 	"fmt"
+	"strconv"
 	"github.com/jerryma0912/Cosmos-sdk-tutorial/x/nameservice/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -21,6 +22,42 @@ func NewHandler(keeper Keeper) sdk.Handler {
 			return handleMsgSetName(ctx, keeper, msg)
 		case types.MsgBuyName:
 			return handleMsgBuyName(ctx, keeper, msg)
+		case types.MsgRenewName:
+			return handleMsgRenewName(ctx, keeper, msg)
+		case types.MsgCommitBid:
+			return handleMsgCommitBid(ctx, keeper, msg)
+		case types.MsgRevealBid:
+			return handleMsgRevealBid(ctx, keeper, msg)
+		case types.MsgCommitName:
+			return handleMsgCommitName(ctx, keeper, msg)
+		case types.MsgTransferName:
+			return handleMsgTransferName(ctx, keeper, msg)
+		case types.MsgAcceptTransfer:
+			return handleMsgAcceptTransfer(ctx, keeper, msg)
+		case types.MsgCancelTransfer:
+			return handleMsgCancelTransfer(ctx, keeper, msg)
+		case types.MsgSetRecord:
+			return handleMsgSetRecord(ctx, keeper, msg)
+		case types.MsgDeleteRecord:
+			return handleMsgDeleteRecord(ctx, keeper, msg)
+		case types.MsgSetPrimaryName:
+			return handleMsgSetPrimaryName(ctx, keeper, msg)
+		case types.MsgCreateSubdomain:
+			return handleMsgCreateSubdomain(ctx, keeper, msg)
+		case types.MsgBurnFuses:
+			return handleMsgBurnFuses(ctx, keeper, msg)
+		case types.MsgSetTLD:
+			return handleMsgSetTLD(ctx, keeper, msg)
+		case types.MsgSetAlias:
+			return handleMsgSetAlias(ctx, keeper, msg)
+		case types.MsgSetAddress:
+			return handleMsgSetAddress(ctx, keeper, msg)
+		case types.MsgSetText:
+			return handleMsgSetText(ctx, keeper, msg)
+		case types.MsgSetContentHash:
+			return handleMsgSetContentHash(ctx, keeper, msg)
+		case types.MsgSetGateway:
+			return handleMsgSetGateway(ctx, keeper, msg)
 		default:
 			errMsg := fmt.Sprintf("Unrecognized nameservice Msg type: %v", msg.Type())
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -36,9 +73,27 @@ func handleMsgSetName(ctx sdk.Context, keeper Keeper, msg MsgSetName) sdk.Result
 		//如果不是，则抛出错误并返回给用户。
 		return sdk.ErrUnauthorized("Incorrect Owner").Result() // If not, throw an error
 	}
+	//过期的域名需要先续期才能修改
+	whois := keeper.GetWhois(ctx, msg.Name)
+	if whois.IsExpired(ctx.BlockHeight()) {
+		return sdk.ErrUnauthorized("Name has expired and must be renewed").Result()
+	}
+	if whois.Fuses.Has(types.FuseCannotSetRecords) {
+		return sdk.ErrUnauthorized("Records of the name are locked").Result()
+	}
+	if maxLen := keeper.MaxValueLength(ctx); uint64(len(msg.Value)) > maxLen {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("Value cannot be longer than %d bytes", maxLen)).Result()
+	}
 	//用Keeper里的函数来设置域名
 	keeper.SetName(ctx, msg.Name, msg.Value) // If so, set the name to the value specified in the msg.
-	return sdk.Result{}                      // return
+	// 返回标签，便于通过 nscli query txs --tags 查找域名的历史记录
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			types.TagCategory, types.TxCategory,
+			types.TagName, msg.Name,
+			types.TagOwner, msg.Owner.String(),
+		),
+	}
 }
 
 // 定义BuyName的handler，该函数执行由msg触发的状态转换。
@@ -47,18 +102,65 @@ func handleMsgSetName(ctx sdk.Context, keeper Keeper, msg MsgSetName) sdk.Result
 // 应在handler中执行依赖于网络状态（例如帐户余额）的验证逻辑。
 // Handle a message to buy name
 func handleMsgBuyName(ctx sdk.Context, keeper Keeper, msg MsgBuyName) sdk.Result {
+	params := keeper.GetParams(ctx)
+	if keeper.HasTLD(ctx, msg.Name) {
+		return sdk.ErrUnauthorized("Top-level domains cannot be registered as names").Result()
+	}
+	// 子域名不能购买，只能由父域名的所有者创建
+	if !keeper.IsRegistrable(ctx, msg.Name) {
+		return sdk.ErrUnauthorized("Subdomains can only be created by the owner of their parent name").Result()
+	}
+	// 域名按其所属 TLD（或根命名空间）的规则注册
+	registry := keeper.GetRegistry(ctx, msg.Name)
+	// 宽限期内的域名只能由之前的所有者续期，不能被购买
+	if keeper.GetWhois(ctx, msg.Name).InGracePeriod(ctx.BlockHeight(), params.GracePeriod) {
+		return sdk.ErrUnauthorized("Name is in its grace period and can only be renewed by its previous owner").Result()
+	}
+	// 拍卖模式下，无主域名只能通过密封竞价获得
+	if registry.Mode == types.RegistrationModeAuction && !keeper.HasOwner(ctx, msg.Name) {
+		return sdk.ErrUnauthorized("Unowned names can only be registered through an auction").Result()
+	}
+	if registry.Mode == types.RegistrationModeRegistrar && !msg.Buyer.Equals(registry.Registrar) {
+		return sdk.ErrUnauthorized(fmt.Sprintf("Names of .%s can only be registered by its registrar", registry.Name)).Result()
+	}
+	if uint64(len(msg.Name)) > params.MaxNameLength {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("Name cannot be longer than %d bytes", params.MaxNameLength)).Result()
+	}
+	// 出价只能使用该 TLD 允许的币种
+	for _, coin := range msg.Bid {
+		if !registry.IsAllowedBidDenom(coin.Denom) {
+			return sdk.ErrInvalidCoins(fmt.Sprintf("Bids cannot be placed in %s", coin.Denom)).Result()
+		}
+	}
+	// 购买必须与购买者之前提交的、已成熟的承诺相匹配，匹配后该承诺被消耗
+	if params.CommitRevealEnabled {
+		hash := types.GetNameCommitment(msg.Name, msg.Buyer, msg.Salt)
+		commitment, found := keeper.GetCommitment(ctx, msg.Buyer, hash)
+		if !found {
+			return sdk.ErrUnauthorized("No commitment found for this name, buyer and salt").Result()
+		}
+		if !commitment.IsMature(ctx.BlockHeight(), params.MinCommitmentAge) {
+			return sdk.ErrUnauthorized("Commitment is not old enough yet").Result()
+		}
+		if commitment.IsStale(ctx.BlockHeight(), params.MaxCommitmentAge) {
+			return sdk.ErrUnauthorized("Commitment has expired").Result()
+		}
+		keeper.DeleteCommitment(ctx, commitment)
+	}
 	// 首先确保出价高于当前价格。然后，检查域名是否已有所有者。如果有，之前的所有者将会收到Buyer的钱。
 	if keeper.GetPrice(ctx, msg.Name).IsAllGT(msg.Bid) { // Checks if the the bid price is greater than the price paid by the current owner
 		return sdk.ErrInsufficientCoins("Bid not high enough").Result() // If not, throw an error
 	}
-	// 如果没有所有者，你的nameservice模块会把Buyer的资金“燃烧”（即发送到不可恢复的地址）。
+	// 如果没有所有者，你的nameservice模块会把Buyer的资金“燃烧”（即发送到不可恢复的地址），
+	// TLD 下的域名则付给该 TLD 的注册商。
+	previousOwner := keeper.GetOwner(ctx, msg.Name)
 	if keeper.HasOwner(ctx, msg.Name) {
-		err := keeper.coinKeeper.SendCoins(ctx, msg.Buyer, keeper.GetOwner(ctx, msg.Name), msg.Bid)
+		err := keeper.coinKeeper.SendCoins(ctx, msg.Buyer, previousOwner, msg.Bid)
 		if err != nil {
 			return sdk.ErrInsufficientCoins("Buyer does not have enough coins").Result()
 		}
 	} else {
-		_, err := keeper.coinKeeper.SubtractCoins(ctx, msg.Buyer, msg.Bid) // If so, deduct the Bid amount from the sender
+		err := keeper.CollectPayment(ctx, msg.Name, msg.Buyer, msg.Bid) // If so, deduct the Bid amount from the sender
 		if err != nil {
 			return sdk.ErrInsufficientCoins("Buyer does not have enough coins").Result()
 		}
@@ -66,5 +168,633 @@ func handleMsgBuyName(ctx sdk.Context, keeper Keeper, msg MsgBuyName) sdk.Result
 	// 使用之前在Keeper上定义的 getter 和 setter，handler 将买方设置为新所有者，并将新价格设置为当前出价。
 	keeper.SetOwner(ctx, msg.Name, msg.Buyer)
 	keeper.SetPrice(ctx, msg.Name, msg.Bid)
-	return sdk.Result{}
+	expires := ctx.BlockHeight() + params.RegistrationPeriod
+	keeper.SetExpires(ctx, msg.Name, expires)
+
+	tags := sdk.NewTags(
+		types.TagCategory, types.TxCategory,
+		types.TagName, msg.Name,
+		types.TagBuyer, msg.Buyer.String(),
+		types.TagOwner, msg.Buyer.String(),
+		types.TagPrice, msg.Bid.String(),
+	)
+	if !previousOwner.Empty() {
+		tags = tags.AppendTag(types.TagPreviousOwner, previousOwner.String())
+	}
+	return sdk.Result{
+		Data: types.ModuleCdc.MustMarshalJSON(types.BuyNameResult{
+			Name:          msg.Name,
+			PreviousOwner: previousOwner,
+			Price:         msg.Bid,
+			Expires:       expires,
+		}),
+		Tags: tags,
+	}
+}
+
+// 续期会从当前的到期高度开始延长一个注册周期，宽限期内同样可以续期。
+// Handle a message to renew name
+func handleMsgRenewName(ctx sdk.Context, keeper Keeper, msg MsgRenewName) sdk.Result {
+	if !keeper.IsRegistrable(ctx, msg.Name) {
+		return sdk.ErrUnknownRequest("Subdomains are renewed together with their parent name").Result()
+	}
+	whois := keeper.GetWhois(ctx, msg.Name)
+	if !msg.Owner.Equals(whois.Owner) {
+		return sdk.ErrUnauthorized("Incorrect Owner").Result()
+	}
+	// 续期费用被燃烧，TLD 下的域名则付给注册商
+	fee := keeper.RenewalFee(ctx)
+	err := keeper.CollectPayment(ctx, msg.Name, msg.Owner, fee)
+	if err != nil {
+		return sdk.ErrInsufficientCoins("Owner does not have enough coins").Result()
+	}
+	expires := whois.Expires + keeper.RegistrationPeriod(ctx)
+	keeper.SetExpires(ctx, msg.Name, expires)
+	return sdk.Result{
+		Data: types.ModuleCdc.MustMarshalJSON(types.RenewNameResult{
+			Name:    msg.Name,
+			Fee:     fee,
+			Expires: expires,
+		}),
+		Tags: sdk.NewTags(
+			types.TagCategory, types.TxCategory,
+			types.TagName, msg.Name,
+			types.TagOwner, msg.Owner.String(),
+			types.TagPrice, fee.String(),
+		),
+	}
+}
+
+// 提交密封出价。第一个出价会开启该域名的拍卖，押金在拍卖结算前被托管。
+// Handle a message to commit a sealed bid
+func handleMsgCommitBid(ctx sdk.Context, keeper Keeper, msg MsgCommitBid) sdk.Result {
+	params := keeper.GetParams(ctx)
+	if keeper.HasTLD(ctx, msg.Name) {
+		return sdk.ErrUnauthorized("Top-level domains cannot be registered as names").Result()
+	}
+	if !keeper.IsRegistrable(ctx, msg.Name) {
+		return sdk.ErrUnauthorized("Subdomains can only be created by the owner of their parent name").Result()
+	}
+	registry := keeper.GetRegistry(ctx, msg.Name)
+	if registry.Mode != types.RegistrationModeAuction {
+		return sdk.ErrUnknownRequest("Auctions are disabled").Result()
+	}
+	if keeper.HasOwner(ctx, msg.Name) {
+		return sdk.ErrUnauthorized("Name already has an owner").Result()
+	}
+	if uint64(len(msg.Name)) > params.MaxNameLength {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("Name cannot be longer than %d bytes", params.MaxNameLength)).Result()
+	}
+	auction, found := keeper.GetAuction(ctx, msg.Name)
+	if !found {
+		auction = types.NewAuction(msg.Name, ctx.BlockHeight(), params.AuctionCommitPeriod, params.AuctionRevealPeriod, registry.AuctionMinPrice())
+	}
+	// 押金必须使用拍卖开始时确定的币种
+	if msg.Deposit.Denom != auction.MinPrice.Denom {
+		return sdk.ErrInvalidCoins(fmt.Sprintf("Deposit must be in %s", auction.MinPrice.Denom)).Result()
+	}
+	if auction.Phase(ctx.BlockHeight()) != types.AuctionPhaseCommit {
+		return sdk.ErrUnauthorized("Commit window of the auction has closed").Result()
+	}
+	if _, found := keeper.GetBid(ctx, msg.Name, msg.Bidder); found {
+		return sdk.ErrUnauthorized("Bidder has already committed a bid for this name").Result()
+	}
+	_, err := keeper.coinKeeper.SubtractCoins(ctx, msg.Bidder, sdk.NewCoins(msg.Deposit))
+	if err != nil {
+		return sdk.ErrInsufficientCoins("Bidder does not have enough coins").Result()
+	}
+	keeper.SetBid(ctx, types.Bid{
+		Name:    msg.Name,
+		Bidder:  msg.Bidder,
+		BidHash: msg.BidHash,
+		Deposit: msg.Deposit,
+	})
+	auction.Bids++
+	keeper.SetAuction(ctx, auction)
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			types.TagCategory, types.TxCategory,
+			types.TagName, msg.Name,
+			types.TagBidder, msg.Bidder.String(),
+		),
+	}
+}
+
+// 揭示出价。只有最高出价者的押金会被继续托管，其他出价者的押金立即退还。
+// 无效的出价（与押金或最低价格不符）不参与竞价，但押金同样会被退还。
+// Handle a message to reveal a sealed bid
+func handleMsgRevealBid(ctx sdk.Context, keeper Keeper, msg MsgRevealBid) sdk.Result {
+	auction, found := keeper.GetAuction(ctx, msg.Name)
+	if !found {
+		return sdk.ErrUnknownRequest("No auction is running for this name").Result()
+	}
+	if auction.Phase(ctx.BlockHeight()) != types.AuctionPhaseReveal {
+		return sdk.ErrUnauthorized("Auction is not in its reveal window").Result()
+	}
+	bid, found := keeper.GetBid(ctx, msg.Name, msg.Bidder)
+	if !found {
+		return sdk.ErrUnauthorized("Bidder has not committed a bid for this name").Result()
+	}
+	if bid.Revealed {
+		return sdk.ErrUnauthorized("Bid has already been revealed").Result()
+	}
+	if !types.VerifyBidHash(bid.BidHash, msg.Name, msg.Bidder, msg.Bid, msg.Salt) {
+		return sdk.ErrUnauthorized("Revealed bid does not match the committed hash").Result()
+	}
+	bid.Revealed = true
+
+	// 与拍卖开始时记录的最低价格比较，币种不同的出价无效
+	minPrice := auction.MinPrice
+	valid := msg.Bid.Denom == minPrice.Denom && bid.Deposit.Denom == msg.Bid.Denom &&
+		bid.Deposit.IsGTE(msg.Bid) && msg.Bid.IsGTE(minPrice)
+	switch {
+	case valid && (auction.HighestBidder.Empty() || msg.Bid.Amount.GT(auction.HighestBid.Amount)):
+		// 新的最高出价：之前的最高出价成为第二高出价，其押金被退还
+		if !auction.HighestBidder.Empty() {
+			previous, _ := keeper.GetBid(ctx, msg.Name, auction.HighestBidder)
+			keeper.RefundBid(ctx, previous)
+			auction.SecondBid = auction.HighestBid
+		}
+		auction.HighestBidder = msg.Bidder
+		auction.HighestBid = msg.Bid
+		keeper.SetBid(ctx, bid)
+	default:
+		if valid && (auction.SecondBid.Denom == "" || msg.Bid.Amount.GT(auction.SecondBid.Amount)) {
+			auction.SecondBid = msg.Bid
+		}
+		keeper.RefundBid(ctx, bid)
+	}
+	keeper.SetAuction(ctx, auction)
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			types.TagCategory, types.TxCategory,
+			types.TagName, msg.Name,
+			types.TagBidder, msg.Bidder.String(),
+		),
+	}
+}
+
+// 提交域名承诺。承诺按提交者存储，在 MinCommitmentAge 到 MaxCommitmentAge 个区块之间可以被揭示。
+// Handle a message to commit to a future name purchase
+func handleMsgCommitName(ctx sdk.Context, keeper Keeper, msg MsgCommitName) sdk.Result {
+	if !keeper.CommitRevealEnabled(ctx) {
+		return sdk.ErrUnknownRequest("Name commitments are disabled").Result()
+	}
+	if existing, found := keeper.GetCommitment(ctx, msg.Committer, msg.Commitment); found {
+		if !existing.IsStale(ctx.BlockHeight(), keeper.MaxCommitmentAge(ctx)) {
+			return sdk.ErrUnauthorized("Commitment already exists").Result()
+		}
+		// 尚未被清理的过期承诺可以被重新提交
+		keeper.DeleteCommitment(ctx, existing)
+	}
+	keeper.SetCommitment(ctx, types.Commitment{
+		Committer: msg.Committer,
+		Hash:      msg.Commitment,
+		Height:    ctx.BlockHeight(),
+	})
+	// 承诺中不包含域名，因此这里不能添加域名标签
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			types.TagCategory, types.TxCategory,
+			types.TagCommitter, msg.Committer.String(),
+		),
+	}
+}
+
+// 转让域名。不需要确认时立即更换所有者，否则记录一个待确认的转让，
+// 再次发起转让会替换之前的待确认转让。
+// Handle a message to transfer name
+func handleMsgTransferName(ctx sdk.Context, keeper Keeper, msg MsgTransferName) sdk.Result {
+	whois := keeper.GetWhois(ctx, msg.Name)
+	if !msg.Owner.Equals(whois.Owner) {
+		return sdk.ErrUnauthorized("Incorrect Owner").Result()
+	}
+	if whois.IsExpired(ctx.BlockHeight()) {
+		return sdk.ErrUnauthorized("Name has expired and must be renewed").Result()
+	}
+	if whois.Fuses.Has(types.FuseCannotTransfer) {
+		return sdk.ErrUnauthorized("Name cannot be transferred").Result()
+	}
+	if msg.RequireAccept {
+		keeper.SetPendingTransfer(ctx, types.PendingTransfer{
+			Name:      msg.Name,
+			Owner:     msg.Owner,
+			Recipient: msg.Recipient,
+			Height:    ctx.BlockHeight(),
+		})
+	} else {
+		// SetOwner 同时清除之前的待确认转让
+		keeper.SetOwner(ctx, msg.Name, msg.Recipient)
+	}
+	return sdk.Result{
+		Data: types.ModuleCdc.MustMarshalJSON(types.TransferNameResult{
+			Name:      msg.Name,
+			Owner:     msg.Owner,
+			Recipient: msg.Recipient,
+			Pending:   msg.RequireAccept,
+		}),
+		Tags: sdk.NewTags(
+			types.TagCategory, types.TxCategory,
+			types.TagName, msg.Name,
+			types.TagOwner, msg.Owner.String(),
+			types.TagRecipient, msg.Recipient.String(),
+		),
+	}
+}
+
+// 接收者接受待确认的转让，域名的价格和到期高度保持不变。
+// Handle a message to accept a pending transfer
+func handleMsgAcceptTransfer(ctx sdk.Context, keeper Keeper, msg MsgAcceptTransfer) sdk.Result {
+	transfer, found := keeper.GetPendingTransfer(ctx, msg.Name)
+	if !found {
+		return sdk.ErrUnknownRequest("No pending transfer for this name").Result()
+	}
+	if !msg.Recipient.Equals(transfer.Recipient) {
+		return sdk.ErrUnauthorized("Incorrect Recipient").Result()
+	}
+	whois := keeper.GetWhois(ctx, msg.Name)
+	if whois.IsExpired(ctx.BlockHeight()) {
+		return sdk.ErrUnauthorized("Name has expired and must be renewed").Result()
+	}
+	// 转让发起后父域名的所有者可能已烧断了 cannot-transfer
+	if whois.Fuses.Has(types.FuseCannotTransfer) {
+		return sdk.ErrUnauthorized("Name cannot be transferred").Result()
+	}
+	keeper.SetOwner(ctx, msg.Name, msg.Recipient)
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			types.TagCategory, types.TxCategory,
+			types.TagName, msg.Name,
+			types.TagOwner, msg.Recipient.String(),
+			types.TagPreviousOwner, transfer.Owner.String(),
+			types.TagRecipient, msg.Recipient.String(),
+		),
+	}
+}
+
+// Handle a message to cancel a pending transfer
+func handleMsgCancelTransfer(ctx sdk.Context, keeper Keeper, msg MsgCancelTransfer) sdk.Result {
+	transfer, found := keeper.GetPendingTransfer(ctx, msg.Name)
+	if !found {
+		return sdk.ErrUnknownRequest("No pending transfer for this name").Result()
+	}
+	if !msg.Owner.Equals(transfer.Owner) {
+		return sdk.ErrUnauthorized("Incorrect Owner").Result()
+	}
+	keeper.DeletePendingTransfer(ctx, msg.Name)
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			types.TagCategory, types.TxCategory,
+			types.TagName, msg.Name,
+			types.TagOwner, msg.Owner.String(),
+			types.TagRecipient, transfer.Recipient.String(),
+		),
+	}
+}
+
+// 添加或更新一条解析记录。与 DNS 一样，CNAME 记录不能与其他记录共存。
+// Handle a message to set a record
+func handleMsgSetRecord(ctx sdk.Context, keeper Keeper, msg MsgSetRecord) sdk.Result {
+	whois := keeper.GetWhois(ctx, msg.Name)
+	if !msg.Owner.Equals(whois.Owner) {
+		return sdk.ErrUnauthorized("Incorrect Owner").Result()
+	}
+	if whois.IsExpired(ctx.BlockHeight()) {
+		return sdk.ErrUnauthorized("Name has expired and must be renewed").Result()
+	}
+	if whois.Fuses.Has(types.FuseCannotSetRecords) {
+		return sdk.ErrUnauthorized("Records of the name are locked").Result()
+	}
+	if maxLen := keeper.MaxValueLength(ctx); uint64(len(msg.Record.Value)) > maxLen {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("Value cannot be longer than %d bytes", maxLen)).Result()
+	}
+	records := keeper.GetRecords(ctx, msg.Name)
+	record := types.NewRecord(msg.Record.Type, msg.Record.Value, msg.Record.TTL)
+	if i := records.Index(record.Type, record.Value); i >= 0 {
+		records[i] = record
+	} else {
+		cnames := len(records.Filter(types.RecordTypeCNAME))
+		if record.Type == types.RecordTypeCNAME && len(records) > 0 {
+			return sdk.ErrUnknownRequest("A CNAME record cannot coexist with other records").Result()
+		}
+		if cnames > 0 {
+			return sdk.ErrUnknownRequest("Name already has a CNAME record").Result()
+		}
+		if len(records) >= types.MaxRecordsPerName {
+			return sdk.ErrUnknownRequest(fmt.Sprintf("Name cannot hold more than %d records", types.MaxRecordsPerName)).Result()
+		}
+		records = append(records, record)
+	}
+	keeper.SetRecords(ctx, msg.Name, records)
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			types.TagCategory, types.TxCategory,
+			types.TagName, msg.Name,
+			types.TagOwner, msg.Owner.String(),
+			types.TagRecordType, record.Type,
+		),
+	}
+}
+
+// 删除一条解析记录，Value 为空时删除该类型的所有记录。
+// Handle a message to delete a record
+func handleMsgDeleteRecord(ctx sdk.Context, keeper Keeper, msg MsgDeleteRecord) sdk.Result {
+	whois := keeper.GetWhois(ctx, msg.Name)
+	if !msg.Owner.Equals(whois.Owner) {
+		return sdk.ErrUnauthorized("Incorrect Owner").Result()
+	}
+	if whois.IsExpired(ctx.BlockHeight()) {
+		return sdk.ErrUnauthorized("Name has expired and must be renewed").Result()
+	}
+	if whois.Fuses.Has(types.FuseCannotSetRecords) {
+		return sdk.ErrUnauthorized("Records of the name are locked").Result()
+	}
+	records := keeper.GetRecords(ctx, msg.Name)
+	kept := Records{}
+	for _, record := range records {
+		if record.Type == msg.RecordType && (msg.Value == "" || record.Value == msg.Value) {
+			continue
+		}
+		kept = append(kept, record)
+	}
+	if len(kept) == len(records) {
+		return sdk.ErrUnknownRequest("No matching record found").Result()
+	}
+	keeper.SetRecords(ctx, msg.Name, kept)
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			types.TagCategory, types.TxCategory,
+			types.TagName, msg.Name,
+			types.TagOwner, msg.Owner.String(),
+			types.TagRecordType, msg.RecordType,
+		),
+	}
+}
+
+// 设置地址的主域名，只能指向发送者自己拥有且未过期的域名。Name 为空时清除主域名。
+// Handle a message to set the primary name of an address
+func handleMsgSetPrimaryName(ctx sdk.Context, keeper Keeper, msg MsgSetPrimaryName) sdk.Result {
+	if msg.Name == "" {
+		keeper.DeletePrimaryName(ctx, msg.Owner)
+		return sdk.Result{
+			Tags: sdk.NewTags(
+				types.TagCategory, types.TxCategory,
+				types.TagOwner, msg.Owner.String(),
+			),
+		}
+	}
+	whois := keeper.GetWhois(ctx, msg.Name)
+	if !msg.Owner.Equals(whois.Owner) {
+		return sdk.ErrUnauthorized("Incorrect Owner").Result()
+	}
+	if whois.IsExpired(ctx.BlockHeight()) {
+		return sdk.ErrUnauthorized("Name has expired and must be renewed").Result()
+	}
+	keeper.SetPrimaryName(ctx, msg.Owner, msg.Name)
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			types.TagCategory, types.TxCategory,
+			types.TagName, msg.Name,
+			types.TagOwner, msg.Owner.String(),
+		),
+	}
+}
+
+// 父域名的所有者创建子域名，不需要出价。子域名的到期高度与父域名相同，
+// 已存在的子域名会被重新分配给新的所有者。
+// Handle a message to create a subdomain
+func handleMsgCreateSubdomain(ctx sdk.Context, keeper Keeper, msg MsgCreateSubdomain) sdk.Result {
+	parentName, _ := types.ParentName(msg.Name)
+	parent := keeper.GetWhois(ctx, parentName)
+	if !msg.Creator.Equals(parent.Owner) {
+		return sdk.ErrUnauthorized("Incorrect Owner of the parent name").Result()
+	}
+	if parent.IsExpired(ctx.BlockHeight()) {
+		return sdk.ErrUnauthorized("Parent name has expired and must be renewed").Result()
+	}
+	if parent.Fuses.Has(types.FuseCannotCreateChildren) {
+		return sdk.ErrUnauthorized("Parent name cannot have new subdomains").Result()
+	}
+	if maxLen := keeper.MaxNameLength(ctx); uint64(len(msg.Name)) > maxLen {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("Name cannot be longer than %d bytes", maxLen)).Result()
+	}
+	previousOwner := keeper.GetOwner(ctx, msg.Name)
+	// 新的子域名以最低价格初始化
+	whois := keeper.GetWhois(ctx, msg.Name)
+	if !previousOwner.Empty() && whois.Fuses.Has(types.FuseCannotReclaim) {
+		return sdk.ErrUnauthorized("Subdomain cannot be reclaimed by the owner of its parent name").Result()
+	}
+	// 子域名被分配给新的所有者时，之前所有者设置的解析数据全部清除，只保留权限位
+	if !previousOwner.Empty() && !previousOwner.Equals(msg.Owner) {
+		fuses := whois.Fuses
+		keeper.DeleteWhois(ctx, msg.Name)
+		whois = keeper.GetWhois(ctx, msg.Name)
+		whois.Fuses = fuses
+	}
+	whois.Owner = msg.Owner
+	whois.Expires = parent.Expires
+	keeper.SetWhois(ctx, msg.Name, whois)
+
+	tags := sdk.NewTags(
+		types.TagCategory, types.TxCategory,
+		types.TagName, msg.Name,
+		types.TagParent, parentName,
+		types.TagOwner, msg.Owner.String(),
+	)
+	if !previousOwner.Empty() {
+		tags = tags.AppendTag(types.TagPreviousOwner, previousOwner.String())
+	}
+	return sdk.Result{Tags: tags}
+}
+
+// 父域名的所有者为子域名烧断权限位。权限位只能增加不能清除，子域名被释放时随之删除。
+// 烧断 cannot-reclaim 后父域名的所有者失去对子域名的控制，此后只有子域名的所有者可以烧断其余权限位。
+// Handle a message to burn fuses of a subdomain
+func handleMsgBurnFuses(ctx sdk.Context, keeper Keeper, msg MsgBurnFuses) sdk.Result {
+	if !keeper.HasOwner(ctx, msg.Name) {
+		return sdk.ErrUnknownRequest("Subdomain does not exist").Result()
+	}
+	whois := keeper.GetWhois(ctx, msg.Name)
+	parentName, _ := types.ParentName(msg.Name)
+	if whois.Fuses.Has(types.FuseCannotReclaim) {
+		if !msg.Signer.Equals(whois.Owner) {
+			return sdk.ErrUnauthorized("The parent has burned cannot-reclaim; only the owner of the subdomain can burn its fuses").Result()
+		}
+	} else {
+		parent := keeper.GetWhois(ctx, parentName)
+		if !msg.Signer.Equals(parent.Owner) {
+			return sdk.ErrUnauthorized("Incorrect Owner of the parent name").Result()
+		}
+		if parent.IsExpired(ctx.BlockHeight()) {
+			return sdk.ErrUnauthorized("Parent name has expired and must be renewed").Result()
+		}
+	}
+	whois.Fuses |= msg.Fuses
+	keeper.SetWhois(ctx, msg.Name, whois)
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			types.TagCategory, types.TxCategory,
+			types.TagName, msg.Name,
+			types.TagParent, parentName,
+			types.TagOwner, whois.Owner.String(),
+			types.TagFuses, whois.Fuses.String(),
+		),
+	}
+}
+
+// 更新顶级域名的注册规则。TLD 只能通过治理提案（CreateTLDProposal）或创世文件创建，
+// 之后由其注册商维护。
+// Handle a message to update a TLD
+func handleMsgSetTLD(ctx sdk.Context, keeper Keeper, msg MsgSetTLD) sdk.Result {
+	tld, found := keeper.GetTLD(ctx, msg.TLD.Name)
+	if !found {
+		return sdk.ErrUnknownRequest("No such TLD; TLDs are created through governance proposals").Result()
+	}
+	if !msg.Signer.Equals(tld.Registrar) {
+		return sdk.ErrUnauthorized("Only the registrar can update a TLD").Result()
+	}
+	keeper.SetTLD(ctx, msg.TLD)
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			types.TagCategory, types.TxCategory,
+			types.TagTLD, msg.TLD.Name,
+			types.TagRegistrar, msg.TLD.Registrar.String(),
+		),
+	}
+}
+
+// 将域名设为另一个域名的别名。别名链中的环路在解析时才会被检测出来，
+// 因为链上的其他域名随时可能被修改。
+// Handle a message to set the alias of a name
+func handleMsgSetAlias(ctx sdk.Context, keeper Keeper, msg MsgSetAlias) sdk.Result {
+	whois := keeper.GetWhois(ctx, msg.Name)
+	if !msg.Owner.Equals(whois.Owner) {
+		return sdk.ErrUnauthorized("Incorrect Owner").Result()
+	}
+	if whois.IsExpired(ctx.BlockHeight()) {
+		return sdk.ErrUnauthorized("Name has expired and must be renewed").Result()
+	}
+	if whois.Fuses.Has(types.FuseCannotSetRecords) {
+		return sdk.ErrUnauthorized("Records of the name are locked").Result()
+	}
+	whois.Alias = msg.Alias
+	keeper.SetWhois(ctx, msg.Name, whois)
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			types.TagCategory, types.TxCategory,
+			types.TagName, msg.Name,
+			types.TagOwner, msg.Owner.String(),
+			types.TagAlias, msg.Alias,
+		),
+	}
+}
+
+// 设置或删除域名在某个币种上的地址。地址格式已在 ValidateBasic 中检查。
+// Handle a message to set the address of a name for a coin type
+func handleMsgSetAddress(ctx sdk.Context, keeper Keeper, msg MsgSetAddress) sdk.Result {
+	whois := keeper.GetWhois(ctx, msg.Name)
+	if !msg.Owner.Equals(whois.Owner) {
+		return sdk.ErrUnauthorized("Incorrect Owner").Result()
+	}
+	if whois.IsExpired(ctx.BlockHeight()) {
+		return sdk.ErrUnauthorized("Name has expired and must be renewed").Result()
+	}
+	if whois.Fuses.Has(types.FuseCannotSetRecords) {
+		return sdk.ErrUnauthorized("Records of the name are locked").Result()
+	}
+	if msg.Address == "" {
+		if keeper.GetAddress(ctx, msg.Name, msg.CoinType) == "" {
+			return sdk.ErrUnknownRequest(fmt.Sprintf("Name has no address for coin type %d", msg.CoinType)).Result()
+		}
+	} else if keeper.GetAddress(ctx, msg.Name, msg.CoinType) == "" && len(keeper.GetAddresses(ctx, msg.Name)) >= types.MaxAddressesPerName {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("Name cannot hold more than %d addresses", types.MaxAddressesPerName)).Result()
+	}
+	keeper.SetAddress(ctx, msg.Name, msg.CoinType, msg.Address)
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			types.TagCategory, types.TxCategory,
+			types.TagName, msg.Name,
+			types.TagOwner, msg.Owner.String(),
+			types.TagCoinType, strconv.FormatUint(uint64(msg.CoinType), 10),
+		),
+	}
+}
+
+// 设置或删除域名的一条文本记录
+// Handle a message to set a text record of a name
+func handleMsgSetText(ctx sdk.Context, keeper Keeper, msg MsgSetText) sdk.Result {
+	whois := keeper.GetWhois(ctx, msg.Name)
+	if !msg.Owner.Equals(whois.Owner) {
+		return sdk.ErrUnauthorized("Incorrect Owner").Result()
+	}
+	if whois.IsExpired(ctx.BlockHeight()) {
+		return sdk.ErrUnauthorized("Name has expired and must be renewed").Result()
+	}
+	if whois.Fuses.Has(types.FuseCannotSetRecords) {
+		return sdk.ErrUnauthorized("Records of the name are locked").Result()
+	}
+	if msg.Value == "" {
+		if keeper.GetText(ctx, msg.Name, msg.Key) == "" {
+			return sdk.ErrUnknownRequest(fmt.Sprintf("Name has no text %s", msg.Key)).Result()
+		}
+	} else if keeper.GetText(ctx, msg.Name, msg.Key) == "" && len(keeper.GetTexts(ctx, msg.Name)) >= types.MaxTextsPerName {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("Name cannot hold more than %d texts", types.MaxTextsPerName)).Result()
+	}
+	keeper.SetText(ctx, msg.Name, msg.Key, msg.Value)
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			types.TagCategory, types.TxCategory,
+			types.TagName, msg.Name,
+			types.TagOwner, msg.Owner.String(),
+			types.TagTextKey, msg.Key,
+		),
+	}
+}
+
+// 设置或删除域名的内容哈希
+// Handle a message to set the content hash of a name
+func handleMsgSetContentHash(ctx sdk.Context, keeper Keeper, msg MsgSetContentHash) sdk.Result {
+	whois := keeper.GetWhois(ctx, msg.Name)
+	if !msg.Owner.Equals(whois.Owner) {
+		return sdk.ErrUnauthorized("Incorrect Owner").Result()
+	}
+	if whois.IsExpired(ctx.BlockHeight()) {
+		return sdk.ErrUnauthorized("Name has expired and must be renewed").Result()
+	}
+	if whois.Fuses.Has(types.FuseCannotSetRecords) {
+		return sdk.ErrUnauthorized("Records of the name are locked").Result()
+	}
+	keeper.SetContentHash(ctx, msg.Name, msg.ContentHash)
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			types.TagCategory, types.TxCategory,
+			types.TagName, msg.Name,
+			types.TagOwner, msg.Owner.String(),
+		),
+	}
+}
+
+// 设置或取消域名的链下网关
+// Handle a message to set the off-chain gateway of a name
+func handleMsgSetGateway(ctx sdk.Context, keeper Keeper, msg MsgSetGateway) sdk.Result {
+	whois := keeper.GetWhois(ctx, msg.Name)
+	if !msg.Owner.Equals(whois.Owner) {
+		return sdk.ErrUnauthorized("Incorrect Owner").Result()
+	}
+	if whois.IsExpired(ctx.BlockHeight()) {
+		return sdk.ErrUnauthorized("Name has expired and must be renewed").Result()
+	}
+	if whois.Fuses.Has(types.FuseCannotSetRecords) {
+		return sdk.ErrUnauthorized("Records of the name are locked").Result()
+	}
+	whois.Gateway = types.NewGateway(msg.URL, msg.Signer)
+	keeper.SetWhois(ctx, msg.Name, whois)
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			types.TagCategory, types.TxCategory,
+			types.TagName, msg.Name,
+			types.TagOwner, msg.Owner.String(),
+			types.TagGateway, msg.URL,
+		),
+	}
 }
@@ -0,0 +1,417 @@
+package nameservice
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/jerryma0912/Cosmos-sdk-tutorial/x/nameservice/types"
+)
+
+func TestExpiryAndGracePeriod(t *testing.T) {
+	ctx, keeper := CreateTestInput(t)
+	params := DefaultParams()
+	params.AuctionsEnabled = false
+	params.CommitRevealEnabled = false
+	params.RegistrationPeriod = 10
+	params.GracePeriod = 5
+	keeper.SetParams(ctx, params)
+	handler := NewHandler(keeper)
+	price := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 2))
+	fund(t, ctx, keeper, addr1, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 10)))
+	fund(t, ctx, keeper, addr2, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 10)))
+
+	// alice is registered until height 11
+	res := handler(ctx, NewMsgBuyName("alice", price, addr1, "secretsalt"))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, int64(11), keeper.GetExpires(ctx, "alice"))
+
+	// an expired name cannot be used, nor bought by anyone else during its grace period
+	graceCtx := ctx.WithBlockHeight(12)
+	res = handler(graceCtx, NewMsgSetName("alice", "1.2.3.4", addr1))
+	require.False(t, res.IsOK())
+	res = handler(graceCtx, NewMsgBuyName("alice", sdk.NewCoins(sdk.NewInt64Coin("nametoken", 5)), addr2, "secretsalt"))
+	require.False(t, res.IsOK())
+
+	// but the previous owner can still renew it, counted from its previous expiry
+	res = handler(graceCtx, NewMsgRenewName("alice", addr1))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, int64(21), keeper.GetExpires(ctx, "alice"))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 7)), keeper.coinKeeper.GetCoins(ctx, addr1))
+
+	// once the grace period has ended the name is released and can be bought again
+	EndBlocker(ctx.WithBlockHeight(26), keeper)
+	require.True(t, keeper.HasOwner(ctx, "alice"))
+	EndBlocker(ctx.WithBlockHeight(27), keeper)
+	require.False(t, keeper.HasOwner(ctx, "alice"))
+	res = handler(ctx.WithBlockHeight(28), NewMsgBuyName("alice", price, addr2, "secretsalt"))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, addr2, keeper.GetOwner(ctx, "alice"))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 8)), keeper.coinKeeper.GetCoins(ctx, addr2))
+}
+
+func TestCommitmentMaturity(t *testing.T) {
+	ctx, keeper := CreateTestInput(t)
+	params := DefaultParams()
+	params.AuctionsEnabled = false
+	params.CommitRevealEnabled = true
+	params.MinCommitmentAge = 2
+	params.MaxCommitmentAge = 4
+	keeper.SetParams(ctx, params)
+	handler := NewHandler(keeper)
+	price := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 1))
+	fund(t, ctx, keeper, addr1, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 10)))
+
+	// empty and short salts are rejected before they reach the handler
+	require.NotNil(t, NewMsgBuyName("alice", price, addr1, "").ValidateBasic())
+	require.NotNil(t, NewMsgBuyName("alice", price, addr1, "short").ValidateBasic())
+	require.Nil(t, NewMsgBuyName("alice", price, addr1, "secretsalt").ValidateBasic())
+
+	// buying requires a commitment
+	res := handler(ctx, NewMsgBuyName("alice", price, addr1, "secretsalt"))
+	require.False(t, res.IsOK())
+
+	res = handler(ctx, NewMsgCommitName(GetNameCommitment("alice", addr1, "secretsalt"), addr1))
+	require.True(t, res.IsOK(), res.Log)
+
+	// the commitment made at height 1 matures at height 3
+	res = handler(ctx.WithBlockHeight(2), NewMsgBuyName("alice", price, addr1, "secretsalt"))
+	require.False(t, res.IsOK())
+	// a commitment for another salt does not match
+	res = handler(ctx.WithBlockHeight(3), NewMsgBuyName("alice", price, addr1, "othersalt"))
+	require.False(t, res.IsOK())
+	res = handler(ctx.WithBlockHeight(3), NewMsgBuyName("alice", price, addr1, "secretsalt"))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, addr1, keeper.GetOwner(ctx, "alice"))
+
+	// the commitment is consumed by the purchase
+	_, found := keeper.GetCommitment(ctx, addr1, GetNameCommitment("alice", addr1, "secretsalt"))
+	require.False(t, found)
+
+	// and is stale once older than the maximum age
+	res = handler(ctx, NewMsgCommitName(GetNameCommitment("bob", addr1, "secretsalt"), addr1))
+	require.True(t, res.IsOK(), res.Log)
+	res = handler(ctx.WithBlockHeight(6), NewMsgBuyName("bob", price, addr1, "secretsalt"))
+	require.False(t, res.IsOK())
+	require.False(t, keeper.HasOwner(ctx, "bob"))
+}
+
+func TestDeleteRecordExpired(t *testing.T) {
+	ctx, keeper := CreateTestInput(t)
+	handler := NewHandler(keeper)
+	price := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 1))
+	keeper.SetWhois(ctx, "alice", Whois{Owner: addr1, Price: price, Expires: 10})
+	keeper.SetRecords(ctx, "alice", Records{NewRecord("A", "192.0.2.1", 60)})
+
+	// records of an expired name can no more be deleted than set
+	res := handler(ctx.WithBlockHeight(11), NewMsgDeleteRecord("alice", "A", "", addr1))
+	require.False(t, res.IsOK())
+	require.Len(t, keeper.GetRecords(ctx, "alice"), 1)
+
+	res = handler(ctx.WithBlockHeight(10), NewMsgDeleteRecord("alice", "A", "", addr1))
+	require.True(t, res.IsOK(), res.Log)
+	require.Empty(t, keeper.GetRecords(ctx, "alice"))
+}
+
+func TestParams(t *testing.T) {
+	ctx, keeper := CreateTestInput(t)
+	handler := NewHandler(keeper)
+
+	// the subspace starts from the defaults and every param can be read on its own
+	defaults := DefaultParams()
+	require.Nil(t, defaults.Validate())
+	require.Equal(t, defaults, keeper.GetParams(ctx))
+	require.Equal(t, defaults.MinNamePrice, keeper.MinNamePrice(ctx))
+	require.Equal(t, defaults.AllowedBidDenoms, keeper.AllowedBidDenoms(ctx))
+	require.Equal(t, defaults.MaxNameLength, keeper.MaxNameLength(ctx))
+	require.Equal(t, defaults.GracePeriod, keeper.GracePeriod(ctx))
+	require.Equal(t, defaults.AuctionsEnabled, keeper.AuctionsEnabled(ctx))
+
+	bz, err := NewQuerier(keeper)(ctx, []string{QueryParams}, abci.RequestQuery{})
+	require.Nil(t, err)
+	var queried Params
+	ModuleCdc.MustUnmarshalJSON(bz, &queried)
+	require.Equal(t, defaults, queried)
+
+	invalid := map[string]func(p *Params){
+		"empty min price":         func(p *Params) { p.MinNamePrice = sdk.Coins{} },
+		"no bid denoms":           func(p *Params) { p.AllowedBidDenoms = nil },
+		"empty bid denom":         func(p *Params) { p.AllowedBidDenoms = []string{"nametoken", " "} },
+		"min price not biddable":  func(p *Params) { p.AllowedBidDenoms = []string{"stake"} },
+		"zero name length":        func(p *Params) { p.MaxNameLength = 0 },
+		"zero value length":       func(p *Params) { p.MaxValueLength = 0 },
+		"zero registration":       func(p *Params) { p.RegistrationPeriod = 0 },
+		"negative grace period":   func(p *Params) { p.GracePeriod = -1 },
+		"zero reveal period":      func(p *Params) { p.AuctionRevealPeriod = 0 },
+		"min commitment over max": func(p *Params) { p.MinCommitmentAge, p.MaxCommitmentAge = 5, 4 },
+	}
+	for name, mutate := range invalid {
+		params := DefaultParams()
+		mutate(&params)
+		require.NotNil(t, params.Validate(), name)
+	}
+
+	// the handlers follow the params set in the subspace
+	params := DefaultParams()
+	params.CommitRevealEnabled = false
+	params.MinNamePrice = sdk.NewCoins(sdk.NewInt64Coin("nametoken", 3))
+	params.AllowedBidDenoms = []string{"nametoken"}
+	params.MaxNameLength = 5
+	keeper.SetParams(ctx, params)
+	require.Equal(t, params, keeper.GetParams(ctx))
+	fund(t, ctx, keeper, addr1, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 10), sdk.NewInt64Coin("stake", 10)))
+
+	res := handler(ctx, NewMsgBuyName("alice", sdk.NewCoins(sdk.NewInt64Coin("nametoken", 2)), addr1, "secretsalt"))
+	require.False(t, res.IsOK())
+	res = handler(ctx, NewMsgBuyName("alice", sdk.NewCoins(sdk.NewInt64Coin("stake", 5)), addr1, "secretsalt"))
+	require.False(t, res.IsOK())
+	res = handler(ctx, NewMsgBuyName("alice1", sdk.NewCoins(sdk.NewInt64Coin("nametoken", 3)), addr1, "secretsalt"))
+	require.False(t, res.IsOK())
+	res = handler(ctx, NewMsgBuyName("alice", sdk.NewCoins(sdk.NewInt64Coin("nametoken", 3)), addr1, "secretsalt"))
+	require.True(t, res.IsOK(), res.Log)
+}
+
+// tagValue returns the value of the tag with the given key, or "" if it is missing
+func tagValue(tags sdk.Tags, key string) string {
+	for _, tag := range tags {
+		if string(tag.Key) == key {
+			return string(tag.Value)
+		}
+	}
+	return ""
+}
+
+func TestResultTags(t *testing.T) {
+	ctx, keeper := CreateTestInput(t)
+	params := DefaultParams()
+	params.CommitRevealEnabled = false
+	params.RegistrationPeriod = 10
+	params.GracePeriod = 0
+	keeper.SetParams(ctx, params)
+	handler := NewHandler(keeper)
+	fund(t, ctx, keeper, addr1, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 10)))
+	fund(t, ctx, keeper, addr2, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 10)))
+
+	// a first purchase has no previous owner
+	res := handler(ctx, NewMsgBuyName("alice", sdk.NewCoins(sdk.NewInt64Coin("nametoken", 2)), addr1, "secretsalt"))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, types.TxCategory, tagValue(res.Tags, types.TagCategory))
+	require.Equal(t, "alice", tagValue(res.Tags, types.TagName))
+	require.Equal(t, addr1.String(), tagValue(res.Tags, types.TagBuyer))
+	require.Equal(t, addr1.String(), tagValue(res.Tags, types.TagOwner))
+	require.Equal(t, "2nametoken", tagValue(res.Tags, types.TagPrice))
+	require.Empty(t, tagValue(res.Tags, types.TagPreviousOwner))
+
+	res = handler(ctx, NewMsgBuyName("alice", sdk.NewCoins(sdk.NewInt64Coin("nametoken", 3)), addr2, "secretsalt"))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, addr1.String(), tagValue(res.Tags, types.TagPreviousOwner))
+	var bought BuyNameResult
+	ModuleCdc.MustUnmarshalJSON(res.Data, &bought)
+	require.Equal(t, BuyNameResult{Name: "alice", PreviousOwner: addr1, Price: sdk.NewCoins(sdk.NewInt64Coin("nametoken", 3)), Expires: 11}, bought)
+
+	res = handler(ctx, NewMsgSetName("alice", "1.2.3.4", addr2))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, "alice", tagValue(res.Tags, types.TagName))
+	require.Equal(t, addr2.String(), tagValue(res.Tags, types.TagOwner))
+
+	res = handler(ctx, NewMsgRenewName("alice", addr2))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, params.RenewalFee.String(), tagValue(res.Tags, types.TagPrice))
+
+	res = handler(ctx, NewMsgTransferName("alice", addr2, addr1, true))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, addr2.String(), tagValue(res.Tags, types.TagOwner))
+	require.Equal(t, addr1.String(), tagValue(res.Tags, types.TagRecipient))
+	var transferred TransferNameResult
+	ModuleCdc.MustUnmarshalJSON(res.Data, &transferred)
+	require.True(t, transferred.Pending)
+
+	// failed messages carry no tags
+	res = handler(ctx, NewMsgSetName("alice", "5.6.7.8", addr1))
+	require.False(t, res.IsOK())
+	require.Empty(t, res.Tags)
+
+	// releasing the name is tagged from the EndBlocker
+	tags := EndBlocker(ctx.WithBlockHeight(22), keeper)
+	require.Equal(t, types.ActionNameReleased, tagValue(tags, types.TagAction))
+	require.Equal(t, "alice", tagValue(tags, types.TagName))
+	require.Equal(t, addr2.String(), tagValue(tags, types.TagPreviousOwner))
+}
+
+func TestTwoStepTransfer(t *testing.T) {
+	ctx, keeper := CreateTestInput(t)
+	params := DefaultParams()
+	params.CommitRevealEnabled = false
+	params.RegistrationPeriod = 10
+	params.GracePeriod = 5
+	keeper.SetParams(ctx, params)
+	handler := NewHandler(keeper)
+	price := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 1))
+	fund(t, ctx, keeper, addr1, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 10)))
+	fund(t, ctx, keeper, addr3, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 10)))
+	res := handler(ctx, NewMsgBuyName("alice", price, addr1, "secretsalt"))
+	require.True(t, res.IsOK(), res.Log)
+
+	// only the recipient can accept, and only while the transfer is pending
+	res = handler(ctx, NewMsgAcceptTransfer("alice", addr2))
+	require.False(t, res.IsOK())
+	res = handler(ctx, NewMsgTransferName("alice", addr1, addr2, true))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, addr1, keeper.GetOwner(ctx, "alice"))
+	res = handler(ctx, NewMsgAcceptTransfer("alice", addr3))
+	require.False(t, res.IsOK())
+	res = handler(ctx, NewMsgAcceptTransfer("alice", addr2))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, addr2, keeper.GetOwner(ctx, "alice"))
+	_, found := keeper.GetPendingTransfer(ctx, "alice")
+	require.False(t, found)
+	res = handler(ctx, NewMsgAcceptTransfer("alice", addr2))
+	require.False(t, res.IsOK())
+
+	// only the owner who started the transfer can cancel it
+	res = handler(ctx, NewMsgTransferName("alice", addr2, addr1, true))
+	require.True(t, res.IsOK(), res.Log)
+	res = handler(ctx, NewMsgCancelTransfer("alice", addr1))
+	require.False(t, res.IsOK())
+	res = handler(ctx, NewMsgCancelTransfer("alice", addr2))
+	require.True(t, res.IsOK(), res.Log)
+	res = handler(ctx, NewMsgAcceptTransfer("alice", addr1))
+	require.False(t, res.IsOK())
+	res = handler(ctx, NewMsgCancelTransfer("alice", addr2))
+	require.False(t, res.IsOK())
+
+	// a new transfer replaces the pending one
+	res = handler(ctx, NewMsgTransferName("alice", addr2, addr1, true))
+	require.True(t, res.IsOK(), res.Log)
+	res = handler(ctx, NewMsgTransferName("alice", addr2, addr3, true))
+	require.True(t, res.IsOK(), res.Log)
+	res = handler(ctx, NewMsgAcceptTransfer("alice", addr1))
+	require.False(t, res.IsOK())
+
+	// an expired name cannot be accepted, and its release drops the transfer
+	res = handler(ctx.WithBlockHeight(12), NewMsgAcceptTransfer("alice", addr3))
+	require.False(t, res.IsOK())
+	EndBlocker(ctx.WithBlockHeight(17), keeper)
+	_, found = keeper.GetPendingTransfer(ctx, "alice")
+	require.False(t, found)
+
+	// so does a change of owner through a purchase
+	res = handler(ctx, NewMsgBuyName("alice", price, addr1, "secretsalt"))
+	require.True(t, res.IsOK(), res.Log)
+	res = handler(ctx, NewMsgTransferName("alice", addr1, addr2, true))
+	require.True(t, res.IsOK(), res.Log)
+	res = handler(ctx, NewMsgBuyName("alice", sdk.NewCoins(sdk.NewInt64Coin("nametoken", 2)), addr3, "secretsalt"))
+	require.True(t, res.IsOK(), res.Log)
+	res = handler(ctx, NewMsgAcceptTransfer("alice", addr2))
+	require.False(t, res.IsOK())
+	require.Equal(t, addr3, keeper.GetOwner(ctx, "alice"))
+}
+
+func TestPrimaryNameClearedOnTransfer(t *testing.T) {
+	ctx, keeper := CreateTestInput(t)
+	params := DefaultParams()
+	params.CommitRevealEnabled = false
+	keeper.SetParams(ctx, params)
+	handler := NewHandler(keeper)
+	price := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 1))
+	fund(t, ctx, keeper, addr1, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 10)))
+	for _, name := range []string{"alice", "bob"} {
+		res := handler(ctx, NewMsgBuyName(name, price, addr1, "secretsalt"))
+		require.True(t, res.IsOK(), res.Log)
+	}
+
+	// only names the address owns can be its primary name
+	res := handler(ctx, NewMsgSetPrimaryName("alice", addr2))
+	require.False(t, res.IsOK())
+	res = handler(ctx, NewMsgSetPrimaryName("alice", addr1))
+	require.True(t, res.IsOK(), res.Log)
+	primary, found := keeper.GetPrimaryName(ctx, addr1)
+	require.True(t, found)
+	require.Equal(t, "alice", primary)
+
+	// neither a pending transfer nor the transfer of another name clears it
+	res = handler(ctx, NewMsgTransferName("alice", addr1, addr2, true))
+	require.True(t, res.IsOK(), res.Log)
+	res = handler(ctx, NewMsgTransferName("bob", addr1, addr2, false))
+	require.True(t, res.IsOK(), res.Log)
+	primary, found = keeper.GetPrimaryName(ctx, addr1)
+	require.True(t, found)
+	require.Equal(t, "alice", primary)
+
+	// accepting the transfer clears it, and the recipient does not inherit it
+	res = handler(ctx, NewMsgAcceptTransfer("alice", addr2))
+	require.True(t, res.IsOK(), res.Log)
+	_, found = keeper.GetPrimaryName(ctx, addr1)
+	require.False(t, found)
+	_, found = keeper.GetPrimaryName(ctx, addr2)
+	require.False(t, found)
+	_, err := NewQuerier(keeper)(ctx, []string{QueryReverse, addr1.String()}, abci.RequestQuery{})
+	require.NotNil(t, err)
+
+	// an immediate transfer clears it as well
+	res = handler(ctx, NewMsgSetPrimaryName("bob", addr2))
+	require.True(t, res.IsOK(), res.Log)
+	res = handler(ctx, NewMsgTransferName("bob", addr2, addr1, false))
+	require.True(t, res.IsOK(), res.Log)
+	_, found = keeper.GetPrimaryName(ctx, addr2)
+	require.False(t, found)
+}
+
+func TestTextRecords(t *testing.T) {
+	ctx, keeper := CreateTestInput(t)
+	handler := NewHandler(keeper)
+	querier := NewQuerier(keeper)
+	price := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 1))
+	keeper.SetWhois(ctx, "alice", Whois{Owner: addr1, Price: price, Expires: 100})
+
+	// keys and values are checked before the messages reach the handler
+	require.Nil(t, NewMsgSetText("alice", "com.github", "alice", addr1).ValidateBasic())
+	require.NotNil(t, NewMsgSetText("alice", "", "alice", addr1).ValidateBasic())
+	require.NotNil(t, NewMsgSetText("alice", "com github", "alice", addr1).ValidateBasic())
+	require.NotNil(t, NewMsgSetText("alice", strings.Repeat("k", types.MaxTextKeyLength+1), "alice", addr1).ValidateBasic())
+	require.NotNil(t, NewMsgSetText("alice", "description", strings.Repeat("v", types.MaxTextValueLength+1), addr1).ValidateBasic())
+
+	res := handler(ctx, NewMsgSetText("alice", "url", "https://example.com", addr2))
+	require.False(t, res.IsOK())
+
+	// a name holds at most MaxTextsPerName texts, existing ones can still be updated
+	for i := 0; i < MaxTextsPerName; i++ {
+		res = handler(ctx, NewMsgSetText("alice", fmt.Sprintf("key%02d", i), "value", addr1))
+		require.True(t, res.IsOK(), res.Log)
+	}
+	res = handler(ctx, NewMsgSetText("alice", "url", "https://example.com", addr1))
+	require.False(t, res.IsOK())
+	res = handler(ctx, NewMsgSetText("alice", "key00", "updated", addr1))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, "updated", keeper.GetText(ctx, "alice", "key00"))
+
+	// an empty value deletes the text and frees its slot
+	res = handler(ctx, NewMsgSetText("alice", "key00", "", addr1))
+	require.True(t, res.IsOK(), res.Log)
+	require.Len(t, keeper.GetTexts(ctx, "alice"), MaxTextsPerName-1)
+	_, err := querier(ctx, []string{QueryText, "alice", "key00"}, abci.RequestQuery{})
+	require.NotNil(t, err)
+	res = handler(ctx, NewMsgSetText("alice", "key00", "", addr1))
+	require.False(t, res.IsOK())
+	res = handler(ctx, NewMsgSetText("alice", "url", "https://example.com", addr1))
+	require.True(t, res.IsOK(), res.Log)
+
+	bz, err := querier(ctx, []string{QueryText, "alice", "url"}, abci.RequestQuery{})
+	require.Nil(t, err)
+	var out QueryResTexts
+	ModuleCdc.MustUnmarshalJSON(bz, &out)
+	require.Equal(t, TextRecords{{Key: "url", Value: "https://example.com"}}, out.Texts)
+
+	// locked records can be neither changed nor deleted
+	whois := keeper.GetWhois(ctx, "alice")
+	whois.Fuses = FuseCannotSetRecords
+	keeper.SetWhois(ctx, "alice", whois)
+	res = handler(ctx, NewMsgSetText("alice", "url", "", addr1))
+	require.False(t, res.IsOK())
+	require.Equal(t, "https://example.com", keeper.GetText(ctx, "alice", "url"))
+}
@@ -3,8 +3,14 @@ package nameservice
 // 处理同存储的交互，引用其他的keeper进行跨模块的交互，
 // 并包含模块的大部分核心功能。
 import (
+	"fmt"
+	"strings"
+
 	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/jerryma0912/Cosmos-sdk-tutorial/x/nameservice/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"	//types包含了整个SDK常用的类型。
 )
@@ -21,15 +27,18 @@ type Keeper struct {
 	storeKey sdk.StoreKey // Unexposed key to access store from sdk.Context
 	// 用于二进制编码/解码的线编解码器,提供负责Cosmos编码格式的工具 -- Amino
 	cdc *codec.Codec // The wire codec for binary encoding/decoding.
+	// 模块参数（最低价格、注册周期等）保存在 params 模块的子空间中，无需升级程序即可修改
+	paramspace params.Subspace
 }
 
 // Keeper的构造函数
 // NewKeeper creates new instances of the nameservice Keeper
-func NewKeeper(coinKeeper bank.Keeper, storeKey sdk.StoreKey, cdc *codec.Codec) Keeper {
+func NewKeeper(coinKeeper bank.Keeper, storeKey sdk.StoreKey, paramspace params.Subspace, cdc *codec.Codec) Keeper {
 	return Keeper{
 		coinKeeper: coinKeeper,
 		storeKey:   storeKey,
 		cdc:        cdc,
+		paramspace: paramspace.WithKeyTable(types.ParamKeyTable()),
 	}
 }
 
@@ -41,27 +50,65 @@ func (k Keeper) SetWhois(ctx sdk.Context, name string, whois Whois) {
 	}
 	//这个函数使用sdk.Context。该对象持有访问像blockHeight和chainID这样重要部分状态的函数。
 	store := ctx.KVStore(k.storeKey)
+	//同步更新到期队列和所有者索引
+	if bz := store.Get(types.GetWhoisKey(name)); bz != nil {
+		var old Whois
+		k.cdc.MustUnmarshalBinaryBare(bz, &old)
+		store.Delete(types.GetExpiryQueueKey(old.Expires, name))
+		store.Delete(types.GetOwnerIndexKey(old.Owner, name))
+		//所有者变更后，之前所有者发起的待确认转让和指向该域名的主域名都失效
+		if !old.Owner.Equals(whois.Owner) {
+			store.Delete(types.GetTransferKey(name))
+			k.releasePrimaryName(ctx, old.Owner, name)
+		}
+	}
+	store.Set(types.GetExpiryQueueKey(whois.Expires, name), []byte{})
+	store.Set(types.GetOwnerIndexKey(whois.Owner, name), []byte{})
+	if parent, ok := types.ParentName(name); ok {
+		store.Set(types.GetChildIndexKey(parent, name), []byte{})
+	}
 	//.Set([]byte,[]byte)向存储中插入<name, value>键值对。
 	// 由于存储只接受[]byte,想要把string转化成[]byte再把它们作为参数传给Set方法。
-	store.Set([]byte(name), k.cdc.MustMarshalBinaryBare(whois))
+	store.Set(types.GetWhoisKey(name), k.cdc.MustMarshalBinaryBare(whois))
 }
 
-
 // Gets the entire Whois metadata struct for a name
 // 添加一个函数来解析域名（即查找域名对应的解析值）
 func (k Keeper) GetWhois(ctx sdk.Context, name string) Whois {
 	//首先使用StoreKey访问存储
 	store := ctx.KVStore(k.storeKey)
-	//如果一个域名尚未在存储中，它返回一个新的 Whois 信息，包含最低价格 MinPrice。
-	if !store.Has([]byte(name)) {
-		return NewWhois()
+	//如果一个域名尚未在存储中，它返回一个新的 Whois 信息，包含其所属 TLD 的最低价格 MinPrice。
+	if !store.Has(types.GetWhoisKey(name)) {
+		return NewWhois(k.GetRegistry(ctx, name).MinPrice)
 	}
-	bz := store.Get([]byte(name))
+	bz := store.Get(types.GetWhoisKey(name))
 	var whois Whois
 	k.cdc.MustUnmarshalBinaryBare(bz, &whois)
 	return whois
 }
 
+// DeleteWhois - removes a name from the store, returning it to the unowned state
+func (k Keeper) DeleteWhois(ctx sdk.Context, name string) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetWhoisKey(name))
+	if bz == nil {
+		return
+	}
+	var whois Whois
+	k.cdc.MustUnmarshalBinaryBare(bz, &whois)
+	store.Delete(types.GetExpiryQueueKey(whois.Expires, name))
+	store.Delete(types.GetOwnerIndexKey(whois.Owner, name))
+	store.Delete(types.GetTransferKey(name))
+	store.Delete(types.GetRecordsKey(name))
+	k.DeleteAddresses(ctx, name)
+	k.DeleteTexts(ctx, name)
+	store.Delete(types.GetContentHashKey(name))
+	if parent, ok := types.ParentName(name); ok {
+		store.Delete(types.GetChildIndexKey(parent, name))
+	}
+	store.Delete(types.GetWhoisKey(name))
+	k.releasePrimaryName(ctx, whois.Owner, name)
+}
 
 // ResolveName - returns the string that the name resolves to
 //根据名称返回域名解析出的值
@@ -69,6 +116,31 @@ func (k Keeper) ResolveName(ctx sdk.Context, name string) string {
 	return k.GetWhois(ctx, name).Value
 }
 
+// FollowAliases - follows the aliases starting at name and returns the name whose value
+// answers for it, after wildcard substitution, together with the chain of names followed.
+// Loops and chains longer than MaxAliasDepth are reported as errors.
+//沿着别名链查找，检测环路并限制深度
+func (k Keeper) FollowAliases(ctx sdk.Context, name string) (source string, chain []string, err sdk.Error) {
+	chain = []string{name}
+	visited := map[string]bool{name: true}
+	for current := name; ; {
+		source, _ = k.ResolveSource(ctx, current)
+		alias := k.GetWhois(ctx, source).Alias
+		if alias == "" {
+			return source, chain, nil
+		}
+		chain = append(chain, alias)
+		if visited[alias] {
+			return "", chain, sdk.ErrUnknownRequest(fmt.Sprintf("alias loop detected: %s", strings.Join(chain, " -> ")))
+		}
+		if len(chain) > types.MaxAliasDepth+1 {
+			return "", chain, sdk.ErrUnknownRequest(fmt.Sprintf("alias chain exceeds the maximum depth of %d", types.MaxAliasDepth))
+		}
+		visited[alias] = true
+		current = alias
+	}
+}
+
 // SetName - sets the value string that a name resolves to
 //设置已有name的whois值为新name
 func (k Keeper) SetName(ctx sdk.Context, name string, value string) {
@@ -111,9 +183,63 @@ func (k Keeper) SetPrice(ctx sdk.Context, name string, price sdk.Coins) {
 	k.SetWhois(ctx, name, whois)
 }
 
+//获取到期高度
+// GetExpires - gets the height at which the registration of a name runs out
+func (k Keeper) GetExpires(ctx sdk.Context, name string) int64 {
+	return k.GetWhois(ctx, name).Expires
+}
+
+//设置到期高度
+// SetExpires - sets the height at which the registration of a name runs out.
+// Subdomains expire together with their parent, so the height is set on all of them as well.
+func (k Keeper) SetExpires(ctx sdk.Context, name string, expires int64) {
+	whois := k.GetWhois(ctx, name)
+	whois.Expires = expires
+	k.SetWhois(ctx, name, whois)
+	for _, child := range k.GetChildren(ctx, name) {
+		k.SetExpires(ctx, child, expires)
+	}
+}
+
 // 获得迭代器，用于遍历指定 store 中的所有 <Key, Value> 对。
 // Get an iterator over all names in which the keys are the names and the values are the whois
 func (k Keeper) GetNamesIterator(ctx sdk.Context) sdk.Iterator {
-	store := ctx.KVStore(k.storeKey)
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.WhoisKeyPrefix)
 	return sdk.KVStorePrefixIterator(store, nil)
 }
+
+// GetNamesIteratorFrom - returns an iterator over all names starting at the given name (inclusive)
+func (k Keeper) GetNamesIteratorFrom(ctx sdk.Context, start string) sdk.Iterator {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.WhoisKeyPrefix)
+	return store.Iterator([]byte(start), nil)
+}
+
+// GetNamesByPrefixIteratorFrom - returns an iterator over the names starting with the
+// given prefix from the name start onwards, in which the keys are the names and the values
+// are the whois
+func (k Keeper) GetNamesByPrefixIteratorFrom(ctx sdk.Context, namePrefix string, start string) sdk.Iterator {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.WhoisKeyPrefix)
+	begin := namePrefix
+	if start > begin {
+		begin = start
+	}
+	return store.Iterator([]byte(begin), sdk.PrefixEndBytes([]byte(namePrefix)))
+}
+
+// 获得某个地址拥有的所有域名的迭代器，键为域名
+// GetNamesByOwnerIterator - returns an iterator over the names owned by an address,
+// in which the keys are the names
+func (k Keeper) GetNamesByOwnerIterator(ctx sdk.Context, owner sdk.AccAddress) sdk.Iterator {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.GetOwnerIndexPrefix(owner))
+	return sdk.KVStorePrefixIterator(store, nil)
+}
+
+// GetExpiredNamesIterator - returns an iterator over the expiry queue entries of all
+// names that expired strictly before the given height
+func (k Keeper) GetExpiredNamesIterator(ctx sdk.Context, height int64) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	if height < 0 {
+		height = 0
+	}
+	return store.Iterator(types.ExpiryQueueKeyPrefix, types.GetExpiryQueueHeightKey(height))
+}
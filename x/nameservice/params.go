@@ -0,0 +1,98 @@
+package nameservice
+
+import (
+	"github.com/jerryma0912/Cosmos-sdk-tutorial/x/nameservice/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// 单独读取某个参数，避免每次都从存储中读取整个参数集
+
+// MinNamePrice - price of a name that has no owner
+func (k Keeper) MinNamePrice(ctx sdk.Context) (res sdk.Coins) {
+	k.paramspace.Get(ctx, types.KeyMinNamePrice, &res)
+	return
+}
+
+// AllowedBidDenoms - denominations accepted in bids
+func (k Keeper) AllowedBidDenoms(ctx sdk.Context) (res []string) {
+	k.paramspace.Get(ctx, types.KeyAllowedBidDenoms, &res)
+	return
+}
+
+// MaxNameLength - maximum length of a name
+func (k Keeper) MaxNameLength(ctx sdk.Context) (res uint64) {
+	k.paramspace.Get(ctx, types.KeyMaxNameLength, &res)
+	return
+}
+
+// MaxValueLength - maximum length of the value a name resolves to
+func (k Keeper) MaxValueLength(ctx sdk.Context) (res uint64) {
+	k.paramspace.Get(ctx, types.KeyMaxValueLength, &res)
+	return
+}
+
+// RegistrationPeriod - number of blocks a purchase or renewal keeps a name registered for
+func (k Keeper) RegistrationPeriod(ctx sdk.Context) (res int64) {
+	k.paramspace.Get(ctx, types.KeyRegistrationPeriod, &res)
+	return
+}
+
+// GracePeriod - number of blocks after expiry during which only the previous owner may renew
+func (k Keeper) GracePeriod(ctx sdk.Context) (res int64) {
+	k.paramspace.Get(ctx, types.KeyGracePeriod, &res)
+	return
+}
+
+// RenewalFee - fee charged for every renewal of a name
+func (k Keeper) RenewalFee(ctx sdk.Context) (res sdk.Coins) {
+	k.paramspace.Get(ctx, types.KeyRenewalFee, &res)
+	return
+}
+
+// AuctionsEnabled - whether unowned names can only be registered through an auction
+func (k Keeper) AuctionsEnabled(ctx sdk.Context) (res bool) {
+	k.paramspace.Get(ctx, types.KeyAuctionsEnabled, &res)
+	return
+}
+
+// AuctionCommitPeriod - number of blocks, counted from the first bid, during which bids are committed
+func (k Keeper) AuctionCommitPeriod(ctx sdk.Context) (res int64) {
+	k.paramspace.Get(ctx, types.KeyAuctionCommitPeriod, &res)
+	return
+}
+
+// AuctionRevealPeriod - number of blocks after the commit window during which bids are revealed
+func (k Keeper) AuctionRevealPeriod(ctx sdk.Context) (res int64) {
+	k.paramspace.Get(ctx, types.KeyAuctionRevealPeriod, &res)
+	return
+}
+
+// CommitRevealEnabled - whether MsgBuyName must match a mature commitment
+func (k Keeper) CommitRevealEnabled(ctx sdk.Context) (res bool) {
+	k.paramspace.Get(ctx, types.KeyCommitRevealEnabled, &res)
+	return
+}
+
+// MinCommitmentAge - minimum number of blocks before a commitment can be revealed
+func (k Keeper) MinCommitmentAge(ctx sdk.Context) (res int64) {
+	k.paramspace.Get(ctx, types.KeyMinCommitmentAge, &res)
+	return
+}
+
+// MaxCommitmentAge - maximum number of blocks a commitment stays valid
+func (k Keeper) MaxCommitmentAge(ctx sdk.Context) (res int64) {
+	k.paramspace.Get(ctx, types.KeyMaxCommitmentAge, &res)
+	return
+}
+
+// GetParams - gets all nameservice parameters
+func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
+	k.paramspace.GetParamSet(ctx, &params)
+	return params
+}
+
+// SetParams - sets all nameservice parameters
+func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
+	k.paramspace.SetParamSet(ctx, &params)
+}
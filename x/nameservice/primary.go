@@ -0,0 +1,46 @@
+package nameservice
+
+// 反向解析：每个地址可以把自己拥有的一个域名设置为主域名。
+// 域名更换所有者或被释放时，SetWhois 和 DeleteWhois 会清除之前所有者指向它的主域名。
+import (
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	"github.com/jerryma0912/Cosmos-sdk-tutorial/x/nameservice/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GetPrimaryName - returns the primary name of an address, if any
+func (k Keeper) GetPrimaryName(ctx sdk.Context, addr sdk.AccAddress) (name string, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetPrimaryNameKey(addr))
+	if bz == nil {
+		return "", false
+	}
+	return string(bz), true
+}
+
+// SetPrimaryName - sets the primary name of an address
+func (k Keeper) SetPrimaryName(ctx sdk.Context, addr sdk.AccAddress, name string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetPrimaryNameKey(addr), []byte(name))
+}
+
+// DeletePrimaryName - clears the primary name of an address
+func (k Keeper) DeletePrimaryName(ctx sdk.Context, addr sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetPrimaryNameKey(addr))
+}
+
+// GetPrimaryNamesIterator - returns an iterator over the primary names of all addresses,
+// in which the keys are the addresses and the values are the names
+func (k Keeper) GetPrimaryNamesIterator(ctx sdk.Context) sdk.Iterator {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.PrimaryNameKeyPrefix)
+	return sdk.KVStorePrefixIterator(store, nil)
+}
+
+// releasePrimaryName - clears the primary name of a previous owner if it still points at name
+func (k Keeper) releasePrimaryName(ctx sdk.Context, previousOwner sdk.AccAddress, name string) {
+	if primary, found := k.GetPrimaryName(ctx, previousOwner); found && primary == name {
+		k.DeletePrimaryName(ctx, previousOwner)
+	}
+}
@@ -0,0 +1,36 @@
+package nameservice
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+// NewProposalHandler returns the handler of the governance proposals of the nameservice module
+func NewProposalHandler(k Keeper) govtypes.Handler {
+	return func(ctx sdk.Context, content govtypes.Content) sdk.Error {
+		switch c := content.(type) {
+		case CreateTLDProposal:
+			return handleCreateTLDProposal(ctx, k, c)
+		default:
+			errMsg := fmt.Sprintf("Unrecognized nameservice proposal content type: %T", c)
+			return sdk.ErrUnknownRequest(errMsg)
+		}
+	}
+}
+
+// 提案通过后创建 TLD。已存在的 TLD 只能由其注册商更新，已被注册或正在拍卖的名称不能成为 TLD
+func handleCreateTLDProposal(ctx sdk.Context, k Keeper, p CreateTLDProposal) sdk.Error {
+	if k.HasTLD(ctx, p.TLD.Name) {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("TLD %s already exists", p.TLD.Name))
+	}
+	if k.HasOwner(ctx, p.TLD.Name) {
+		return sdk.ErrUnauthorized(fmt.Sprintf("Name %s is already owned and cannot become a TLD", p.TLD.Name))
+	}
+	if _, found := k.GetAuction(ctx, p.TLD.Name); found {
+		return sdk.ErrUnauthorized(fmt.Sprintf("Name %s is being auctioned and cannot become a TLD", p.TLD.Name))
+	}
+	k.SetTLD(ctx, p.TLD)
+	return nil
+}
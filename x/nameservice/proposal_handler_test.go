@@ -0,0 +1,47 @@
+package nameservice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestCreateTLDProposal(t *testing.T) {
+	ctx, keeper := CreateTestInput(t)
+	proposalHandler := NewProposalHandler(keeper)
+	handler := NewHandler(keeper)
+
+	price := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 2))
+	tld := NewTLD("dev", addr1, price, []string{"nametoken"}, RegistrationModeOpen)
+
+	// TLDs cannot be created with MsgSetTLD, only through a proposal
+	res := handler(ctx, NewMsgSetTLD(tld, addr1))
+	require.False(t, res.IsOK())
+	require.False(t, keeper.HasTLD(ctx, "dev"))
+
+	proposal := NewCreateTLDProposal("Create dev", "A TLD for developers", tld)
+	require.NoError(t, proposal.ValidateBasic())
+	require.Nil(t, proposalHandler(ctx, proposal))
+	got, found := keeper.GetTLD(ctx, "dev")
+	require.True(t, found)
+	require.Equal(t, tld, got)
+
+	// an existing TLD cannot be replaced by another proposal
+	require.NotNil(t, proposalHandler(ctx, proposal))
+
+	// only the registrar can update the TLD
+	updated := NewTLD("dev", addr2, price, []string{"nametoken"}, RegistrationModeRegistrar)
+	res = handler(ctx, NewMsgSetTLD(updated, addr2))
+	require.False(t, res.IsOK())
+	res = handler(ctx, NewMsgSetTLD(updated, addr1))
+	require.True(t, res.IsOK(), res.Log)
+	got, _ = keeper.GetTLD(ctx, "dev")
+	require.Equal(t, updated, got)
+
+	// an owned name cannot become a TLD
+	keeper.SetOwner(ctx, "app", addr1)
+	require.NotNil(t, proposalHandler(ctx, NewCreateTLDProposal("Create app", "taken", NewTLD("app", addr1, price, []string{"nametoken"}, RegistrationModeOpen))))
+	require.False(t, keeper.HasTLD(ctx, "app"))
+}
@@ -2,7 +2,12 @@ package nameservice
 
 // 在这里定义应用程序用户可以对那些状态进行查询。
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/jerryma0912/Cosmos-sdk-tutorial/x/nameservice/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -15,13 +20,62 @@ const (
 	// 传入一个域名返回价格，解析值和域名的所有者。用于确定你想要购买名称的成本。
 	QueryWhois = "whois"
 	QueryNames = "names"
+	// 传入一个域名返回该域名正在进行的拍卖的状态
+	QueryAuction = "auction"
+	// 返回模块当前的参数
+	QueryParams = "params"
+	// 传入一个地址返回该地址拥有的所有域名
+	QueryOwner = "owner"
+	// 按前缀和子串搜索域名，返回域名的所有者和价格
+	QuerySearch = "search"
+	// 传入一组域名，返回每个域名是否已被拥有、当前价格以及相似的可用域名
+	QueryAvailability = "available"
+	// 传入一个域名返回该域名待确认的转让
+	QueryTransfer = "transfer"
+	// 传入一个域名和可选的记录类型，返回该域名的解析记录
+	QueryRecords = "records"
+	// 传入一个地址返回该地址的主域名（反向解析）
+	QueryReverse = "reverse"
+	// whois 的子路径，返回域名的直接子域名
+	QueryChildren = "children"
+	// 传入一个顶级域名返回它的注册商和注册规则
+	QueryTLD = "tld"
+	// 返回所有顶级域名
+	QueryTLDs = "tlds"
+	// 传入一个域名和可选的 SLIP-44 币种，返回该域名在各条链上的地址
+	QueryAddress = "addr"
+	// 传入一个域名和可选的键，返回该域名的文本记录
+	QueryText = "text"
+	// 传入一个域名返回该域名的内容哈希
+	QueryContentHash = "contenthash"
 )
 
+// queries whose path starts with a name, which is normalized before it is looked up
+var nameQueries = map[string]bool{
+	QueryResolve:     true,
+	QueryWhois:       true,
+	QueryAuction:     true,
+	QueryTransfer:    true,
+	QueryRecords:     true,
+	QueryTLD:         true,
+	QueryAddress:     true,
+	QueryText:        true,
+	QueryContentHash: true,
+}
+
 // 该函数充当查询此模块的子路由器
 // 因为querier没有类似于Msg的接口，所以需要手动定义switch语句（它们无法从query.Route()函数中删除）
 // NewQuerier is the module level router for state queries
 func NewQuerier(keeper Keeper) sdk.Querier {
 	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		// 查询中的域名先转换为规范形式，"Alice" 与 "alice" 查到的是同一个域名
+		if nameQueries[path[0]] && len(path) > 1 {
+			name, err := types.NormalizeName(path[1])
+			if err != nil {
+				return nil, sdk.ErrUnknownRequest(err.Error())
+			}
+			path = append([]string{path[0], name}, path[2:]...)
+		}
 		switch path[0] {
 		case QueryResolve:
 			return queryResolve(ctx, path[1:], req, keeper)
@@ -29,6 +83,32 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			return queryWhois(ctx, path[1:], req, keeper)
 		case QueryNames:
 			return queryNames(ctx, req, keeper)
+		case QueryAuction:
+			return queryAuction(ctx, path[1:], req, keeper)
+		case QueryParams:
+			return queryParams(ctx, keeper)
+		case QueryOwner:
+			return queryOwner(ctx, path[1:], req, keeper)
+		case QuerySearch:
+			return querySearch(ctx, req, keeper)
+		case QueryAvailability:
+			return queryAvailability(ctx, req, keeper)
+		case QueryTransfer:
+			return queryTransfer(ctx, path[1:], req, keeper)
+		case QueryRecords:
+			return queryRecords(ctx, path[1:], req, keeper)
+		case QueryReverse:
+			return queryReverse(ctx, path[1:], req, keeper)
+		case QueryTLD:
+			return queryTLD(ctx, path[1:], req, keeper)
+		case QueryTLDs:
+			return queryTLDs(ctx, keeper)
+		case QueryAddress:
+			return queryAddress(ctx, path[1:], req, keeper)
+		case QueryText:
+			return queryText(ctx, path[1:], req, keeper)
+		case QueryContentHash:
+			return queryContentHash(ctx, path[1:], req, keeper)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown nameservice query endpoint")
 		}
@@ -37,9 +117,18 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 
 // nolint: unparam
 func queryResolve(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
-	value := keeper.ResolveName(ctx, path[0])
+	// 不存在的子域名由其最近祖先的通配符 *.parent 解析
+	first, wildcard := keeper.ResolveSource(ctx, path[0])
+	// 别名被跟随到链的末端
+	source, chain, aliasErr := keeper.FollowAliases(ctx, path[0])
+	if aliasErr != nil {
+		return []byte{}, aliasErr
+	}
+	value := keeper.ResolveName(ctx, source)
+	// 设置了网关的域名由客户端到网关获取签名的记录
+	gateway := keeper.GetWhois(ctx, source).Gateway
 
-	if value == "" {
+	if value == "" && gateway.Empty() {
 		return []byte{}, sdk.ErrUnknownRequest("could not resolve name")
 	}
 	// 按照惯例，每个输出类型都应该是 JSON marshallable 和 stringable（实现 Golang fmt.Stringer 接口）。
@@ -47,7 +136,14 @@ func queryResolve(ctx sdk.Context, path []string, req abci.RequestQuery, keeper
 	// 因此，对于输出类型的解析，我们将解析字符串包装在一个名为 QueryResResolve 的结构中，
 	// 该结构既是JSON marshallable 的又有.String（）方法。
 	// 在type/querier.go中
-	res, err := codec.MarshalJSONIndent(keeper.cdc, QueryResResolve{value})
+	out := QueryResResolve{Value: value, Chain: chain}
+	if wildcard {
+		out.Wildcard = first
+	}
+	if !gateway.Empty() {
+		out.Gateway = &gateway
+	}
+	res, err := codec.MarshalJSONIndent(keeper.cdc, out)
 	if err != nil {
 		panic("could not marshal result to JSON")
 	}
@@ -57,6 +153,14 @@ func queryResolve(ctx sdk.Context, path []string, req abci.RequestQuery, keeper
 
 // nolint: unparam
 func queryWhois(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	// whois/{name}/children 返回该域名的直接子域名
+	if len(path) > 1 && path[1] == QueryChildren {
+		res, err := codec.MarshalJSONIndent(keeper.cdc, QueryResNames(keeper.GetChildren(ctx, path[0])))
+		if err != nil {
+			panic("could not marshal result to JSON")
+		}
+		return res, nil
+	}
 	whois := keeper.GetWhois(ctx, path[0])
 	// 对于 Whois 的输出，正常的 Whois 结构已经是 JSON marshallable 的，
 	// 但我们需要在其上添加.String（）方法。 ??
@@ -68,16 +172,227 @@ func queryWhois(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Ke
 	return res, nil
 }
 
+// queryNames returns one page of names. Pages are either counted with Page/Limit or
+// continued from the NextKey cursor of the previous page, so that the whole store is
+// never loaded into a single response.
 func queryNames(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
-	var namesList QueryResNames
-
-	iterator := keeper.GetNamesIterator(ctx)
+	params := types.NewQueryNamesParams(1, types.DefaultNamesLimit, "", "")
+	if len(req.Data) > 0 {
+		if err := keeper.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+			return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+		}
+	}
+	if params.Limit <= 0 || params.Limit > types.MaxNamesLimit {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("limit must be between 1 and %d", types.MaxNamesLimit))
+	}
+	skip := 0
+	if params.StartKey == "" {
+		if params.Page <= 0 {
+			return nil, sdk.ErrUnknownRequest("page must be greater than 0")
+		}
+		skip = (params.Page - 1) * params.Limit
+	}
 
+	page := QueryResNamesPage{Names: QueryResNames{}}
+	var iterator sdk.Iterator
+	if params.Parent != "" {
+		iterator = keeper.GetChildrenIteratorFrom(ctx, params.Parent, params.StartKey)
+	} else {
+		iterator = keeper.GetNamesIteratorFrom(ctx, params.StartKey)
+	}
+	defer iterator.Close()
 	for ; iterator.Valid(); iterator.Next() {
-		namesList = append(namesList, string(iterator.Key()))
+		if skip > 0 {
+			skip--
+			continue
+		}
+		// 多取一个域名作为下一页的起始游标
+		if len(page.Names) == params.Limit {
+			page.NextKey = string(iterator.Key())
+			break
+		}
+		page.Names = append(page.Names, string(iterator.Key()))
 	}
 	//名称查询的输出也一样，[]字符串本身已经可 marshallable ，但我们需要在其上添加.String（）方法。
 	// 在type/querier.go中
+	res, err := codec.MarshalJSONIndent(keeper.cdc, page)
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+// nolint: unparam
+func queryAuction(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	auction, found := keeper.GetAuction(ctx, path[0])
+	if !found {
+		return []byte{}, sdk.ErrUnknownRequest("no auction is running for name")
+	}
+
+	res, err := codec.MarshalJSONIndent(keeper.cdc, QueryResAuction{
+		Auction: auction,
+		Phase:   auction.Phase(ctx.BlockHeight()),
+	})
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+// nolint: unparam
+func queryRecords(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	recordType := ""
+	if len(path) > 1 {
+		recordType = strings.ToUpper(path[1])
+		if !types.IsValidRecordType(recordType) {
+			return []byte{}, sdk.ErrUnknownRequest(fmt.Sprintf("unsupported record type %s", recordType))
+		}
+	}
+
+	source, wildcard := keeper.ResolveSource(ctx, path[0])
+	out := QueryResRecords{Name: path[0], Records: Records{}}
+	// 已过期的域名（包括通配符）不再应答记录
+	if !keeper.GetWhois(ctx, source).IsExpired(ctx.BlockHeight()) {
+		out.Records = keeper.ResolveRecords(ctx, source, recordType)
+		if wildcard {
+			out.Wildcard = source
+		}
+	}
+	res, err := codec.MarshalJSONIndent(keeper.cdc, out)
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+// nolint: unparam
+func queryAddress(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	source, wildcard := keeper.ResolveSource(ctx, path[0])
+	out := QueryResAddresses{Name: path[0], Addresses: keeper.GetAddresses(ctx, source)}
+	if wildcard {
+		out.Wildcard = source
+	}
+	if len(path) > 1 {
+		coinType, err := strconv.ParseUint(path[1], 10, 32)
+		if err != nil {
+			return []byte{}, sdk.ErrUnknownRequest(fmt.Sprintf("invalid coin type %s", path[1]))
+		}
+		addr := keeper.GetAddress(ctx, source, uint32(coinType))
+		if addr == "" {
+			return []byte{}, sdk.ErrUnknownRequest(fmt.Sprintf("name has no address for coin type %d", coinType))
+		}
+		out.Addresses = AddressRecords{{CoinType: uint32(coinType), Address: addr}}
+	}
+	res, err := codec.MarshalJSONIndent(keeper.cdc, out)
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+// nolint: unparam
+func queryText(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	source, wildcard := keeper.ResolveSource(ctx, path[0])
+	out := QueryResTexts{Name: path[0], Texts: keeper.GetTexts(ctx, source)}
+	if wildcard {
+		out.Wildcard = source
+	}
+	if len(path) > 1 {
+		key := strings.Join(path[1:], "/")
+		value := keeper.GetText(ctx, source, key)
+		if value == "" {
+			return []byte{}, sdk.ErrUnknownRequest(fmt.Sprintf("name has no text %s", key))
+		}
+		out.Texts = TextRecords{{Key: key, Value: value}}
+	}
+	res, err := codec.MarshalJSONIndent(keeper.cdc, out)
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+// nolint: unparam
+func queryContentHash(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	source, wildcard := keeper.ResolveSource(ctx, path[0])
+	contentHash := keeper.GetContentHash(ctx, source)
+	if len(contentHash) == 0 {
+		return []byte{}, sdk.ErrUnknownRequest("name has no content hash")
+	}
+	out := NewQueryResContentHash(path[0], contentHash)
+	if wildcard {
+		out.Wildcard = source
+	}
+	res, err := codec.MarshalJSONIndent(keeper.cdc, out)
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+// nolint: unparam
+func queryReverse(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	addr, err := sdk.AccAddressFromBech32(path[0])
+	if err != nil {
+		return []byte{}, sdk.ErrInvalidAddress(err.Error())
+	}
+	name, found := keeper.GetPrimaryName(ctx, addr)
+	if !found {
+		return []byte{}, sdk.ErrUnknownRequest("address has no primary name")
+	}
+
+	res, err := codec.MarshalJSONIndent(keeper.cdc, QueryResReverse{Address: addr, Name: name})
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+// nolint: unparam
+func queryTransfer(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	transfer, found := keeper.GetPendingTransfer(ctx, path[0])
+	if !found {
+		return []byte{}, sdk.ErrUnknownRequest("no pending transfer for name")
+	}
+
+	res, err := codec.MarshalJSONIndent(keeper.cdc, transfer)
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+func queryParams(ctx sdk.Context, keeper Keeper) ([]byte, sdk.Error) {
+	res, err := codec.MarshalJSONIndent(keeper.cdc, keeper.GetParams(ctx))
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+// nolint: unparam
+func queryOwner(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	owner, err := sdk.AccAddressFromBech32(path[0])
+	if err != nil {
+		return []byte{}, sdk.ErrInvalidAddress(err.Error())
+	}
+
+	namesList := QueryResNames{}
+	iterator := keeper.GetNamesByOwnerIterator(ctx, owner)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		namesList = append(namesList, string(iterator.Key()))
+	}
+
 	res, err := codec.MarshalJSONIndent(keeper.cdc, namesList)
 	if err != nil {
 		panic("could not marshal result to JSON")
@@ -85,3 +400,160 @@ func queryNames(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte,
 
 	return res, nil
 }
+
+// querySearch scans the names starting with the requested prefix and filters them by
+// substring. At most MaxSearchScan names are scanned per query, so a search without a
+// prefix is continued page by page from the NextKey cursor like queryNames.
+func querySearch(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var params QuerySearchParams
+	if err := keeper.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+	// 域名以 UTS #46 映射后的 ASCII 形式存储，搜索词按同样的规则映射
+	params.Prefix, params.Contains = types.NormalizeSearchTerm(params.Prefix), types.NormalizeSearchTerm(params.Contains)
+	if params.Prefix == "" && params.Contains == "" {
+		return nil, sdk.ErrUnknownRequest("search requires a prefix or a substring")
+	}
+	if params.Limit == 0 {
+		params.Limit = types.DefaultNamesLimit
+	}
+	if params.Limit < 0 || params.Limit > types.MaxNamesLimit {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("limit must be between 1 and %d", types.MaxNamesLimit))
+	}
+
+	page := QueryResSearchPage{Results: QueryResSearch{}}
+	iterator := keeper.GetNamesByPrefixIteratorFrom(ctx, params.Prefix, params.StartKey)
+	defer iterator.Close()
+	scanned := 0
+	for ; iterator.Valid(); iterator.Next() {
+		name := string(iterator.Key())
+		// 结果已满或扫描数达到上限时，以当前域名作为下一页的起始游标
+		if len(page.Results) == params.Limit || scanned == types.MaxSearchScan {
+			page.NextKey = name
+			break
+		}
+		scanned++
+		if !strings.Contains(name, params.Contains) {
+			continue
+		}
+		var whois Whois
+		keeper.cdc.MustUnmarshalBinaryBare(iterator.Value(), &whois)
+		page.Results = append(page.Results, NameSummary{
+			Name:    name,
+			Owner:   whois.Owner,
+			Price:   whois.Price,
+			Expires: whois.Expires,
+		})
+	}
+
+	res, err := codec.MarshalJSONIndent(keeper.cdc, page)
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+func queryAvailability(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var params QueryAvailabilityParams
+	if err := keeper.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+	if len(params.Names) == 0 || len(params.Names) > types.MaxAvailabilityNames {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("between 1 and %d names must be given", types.MaxAvailabilityNames))
+	}
+
+	results := make(QueryResAvailability, len(params.Names))
+	for i, name := range params.Names {
+		name, err := types.NormalizeName(name)
+		if err != nil {
+			return nil, sdk.ErrUnknownRequest(err.Error())
+		}
+		whois := keeper.GetWhois(ctx, name)
+		results[i] = NameAvailability{
+			Name:         name,
+			Owned:        !whois.Owner.Empty(),
+			Owner:        whois.Owner,
+			Price:        whois.Price,
+			Alternatives: []string{},
+		}
+		if results[i].Owned {
+			results[i].Alternatives = suggestAlternatives(ctx, keeper, name)
+		}
+	}
+
+	res, err := codec.MarshalJSONIndent(keeper.cdc, results)
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+// alternativeAffixes are combined with an owned name to suggest similar free names
+var alternativeAffixes = []struct{ prefix, suffix string }{
+	{"", "1"}, {"", "2"}, {"", "app"}, {"", "hq"}, {"get", ""},
+	{"my", ""}, {"the", ""}, {"", "-dao"}, {"", "-labs"}, {"", "3"},
+}
+
+// suggestAlternatives returns up to MaxAlternatives unowned names similar to name that
+// can still be bought: the affixes are added to the leftmost label, so that the
+// alternatives stay under the same TLD, and the names must be registrable by anyone
+func suggestAlternatives(ctx sdk.Context, keeper Keeper, name string) []string {
+	maxLength := keeper.MaxNameLength(ctx)
+	// 词缀加在最左侧标签的 Unicode 形式上，再转换回规范形式
+	label, rest := types.DisplayName(name), ""
+	if i := strings.Index(label, "."); i >= 0 {
+		label, rest = label[:i], label[i:]
+	}
+	alternatives := []string{}
+	for _, affix := range alternativeAffixes {
+		if len(alternatives) == types.MaxAlternatives {
+			break
+		}
+		candidate, err := types.NormalizeName(affix.prefix + label + affix.suffix + rest)
+		if err != nil || uint64(len(candidate)) > maxLength || keeper.HasOwner(ctx, candidate) {
+			continue
+		}
+		// 子域名及只能由注册商注册的域名不能被购买
+		if keeper.HasTLD(ctx, candidate) || !keeper.IsRegistrable(ctx, candidate) ||
+			keeper.GetRegistry(ctx, candidate).Mode == types.RegistrationModeRegistrar {
+			continue
+		}
+		alternatives = append(alternatives, candidate)
+	}
+	return alternatives
+}
+
+// nolint: unparam
+func queryTLD(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	tld, found := keeper.GetTLD(ctx, path[0])
+	if !found {
+		return []byte{}, sdk.ErrUnknownRequest("no such TLD")
+	}
+
+	res, err := codec.MarshalJSONIndent(keeper.cdc, tld)
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
+
+func queryTLDs(ctx sdk.Context, keeper Keeper) ([]byte, sdk.Error) {
+	tlds := QueryResTLDs{}
+	iterator := keeper.GetTLDsIterator(ctx)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var tld TLD
+		keeper.cdc.MustUnmarshalBinaryBare(iterator.Value(), &tld)
+		tlds = append(tlds, tld)
+	}
+
+	res, err := codec.MarshalJSONIndent(keeper.cdc, tlds)
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return res, nil
+}
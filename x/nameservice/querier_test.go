@@ -0,0 +1,326 @@
+package nameservice
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestQuerySearch(t *testing.T) {
+	ctx, keeper := CreateTestInput(t)
+	querier := NewQuerier(keeper)
+	price := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 1))
+	for _, name := range []string{"alice", "xn--bcher-kva", "bob", "api.alice"} {
+		keeper.SetWhois(ctx, name, Whois{Owner: addr1, Price: price, Expires: 100})
+	}
+
+	search := func(prefix, contains string) []string {
+		data := ModuleCdc.MustMarshalJSON(NewQuerySearchParams(prefix, contains, "", 0))
+		bz, err := querier(ctx, []string{QuerySearch}, abci.RequestQuery{Data: data})
+		require.Nil(t, err)
+		var res QueryResSearchPage
+		ModuleCdc.MustUnmarshalJSON(bz, &res)
+		names := []string{}
+		for _, summary := range res.Results {
+			names = append(names, summary.Name)
+		}
+		return names
+	}
+
+	// search terms are mapped like names before they are matched
+	require.Equal(t, []string{"alice"}, search("ＡＬＩＣＥ", ""))
+	require.Equal(t, []string{"xn--bcher-kva"}, search("Bücher", ""))
+	require.Equal(t, []string{"api.alice"}, search("", "API.Alice"))
+	require.Equal(t, []string{"alice", "api.alice"}, search("", "LIC"))
+	// non-ASCII fragments only match whole labels
+	require.Empty(t, search("bü", ""))
+}
+
+func TestQuerySearchPages(t *testing.T) {
+	ctx, keeper := CreateTestInput(t)
+	querier := NewQuerier(keeper)
+	price := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 1))
+	// MaxSearchScan 个不匹配的域名排在匹配的域名之前
+	for i := 0; i < MaxSearchScan; i++ {
+		keeper.SetWhois(ctx, fmt.Sprintf("a%04d", i), Whois{Owner: addr1, Price: price, Expires: 100})
+	}
+	for _, name := range []string{"bob", "bobby", "zbob"} {
+		keeper.SetWhois(ctx, name, Whois{Owner: addr1, Price: price, Expires: 100})
+	}
+
+	search := func(prefix, contains, start string, limit int) ([]string, string) {
+		data := ModuleCdc.MustMarshalJSON(NewQuerySearchParams(prefix, contains, start, limit))
+		bz, err := querier(ctx, []string{QuerySearch}, abci.RequestQuery{Data: data})
+		require.Nil(t, err)
+		var res QueryResSearchPage
+		ModuleCdc.MustUnmarshalJSON(bz, &res)
+		names := []string{}
+		for _, summary := range res.Results {
+			names = append(names, summary.Name)
+		}
+		return names, res.NextKey
+	}
+
+	// a substring search without a prefix stops after MaxSearchScan names
+	names, next := search("", "bob", "", 0)
+	require.Empty(t, names)
+	require.Equal(t, "bob", next)
+	names, next = search("", "bob", next, 0)
+	require.Equal(t, []string{"bob", "bobby", "zbob"}, names)
+	require.Empty(t, next)
+
+	// full pages are continued from the next key, within the prefix
+	names, next = search("bob", "", "", 1)
+	require.Equal(t, []string{"bob"}, names)
+	require.Equal(t, "bobby", next)
+	names, next = search("bob", "", next, 1)
+	require.Equal(t, []string{"bobby"}, names)
+	require.Empty(t, next)
+	// a start key before the prefix does not leave it
+	names, _ = search("bob", "", "a0001", 0)
+	require.Equal(t, []string{"bob", "bobby"}, names)
+}
+
+func TestFollowAliases(t *testing.T) {
+	ctx, keeper := CreateTestInput(t)
+	querier := NewQuerier(keeper)
+	price := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 1))
+	setAlias := func(name, alias, value string) {
+		keeper.SetWhois(ctx, name, Whois{Owner: addr1, Price: price, Expires: 100, Alias: alias, Value: value})
+	}
+	resolve := func(name string) (QueryResResolve, sdk.Error) {
+		var out QueryResResolve
+		bz, err := querier(ctx, []string{QueryResolve, name}, abci.RequestQuery{})
+		if err == nil {
+			ModuleCdc.MustUnmarshalJSON(bz, &out)
+		}
+		return out, err
+	}
+
+	// a -> b -> c resolves to the value of c and reports the chain followed
+	setAlias("a", "b", "")
+	setAlias("b", "c", "")
+	setAlias("c", "", "1.2.3.4")
+	source, chain, err := keeper.FollowAliases(ctx, "a")
+	require.Nil(t, err)
+	require.Equal(t, "c", source)
+	require.Equal(t, []string{"a", "b", "c"}, chain)
+	out, err := resolve("a")
+	require.Nil(t, err)
+	require.Equal(t, "1.2.3.4", out.Value)
+	require.Equal(t, []string{"a", "b", "c"}, out.Chain)
+
+	// x -> y -> x is a loop
+	setAlias("x", "y", "")
+	setAlias("y", "x", "")
+	_, chain, err = keeper.FollowAliases(ctx, "x")
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "alias loop detected: x -> y -> x")
+	require.Equal(t, []string{"x", "y", "x"}, chain)
+	_, err = resolve("x")
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "alias loop detected")
+
+	// a chain of MaxAliasDepth aliases is followed, one more is rejected
+	for i := 0; i <= MaxAliasDepth; i++ {
+		setAlias(fmt.Sprintf("n%d", i), fmt.Sprintf("n%d", i+1), "")
+	}
+	setAlias(fmt.Sprintf("n%d", MaxAliasDepth+1), "", "5.6.7.8")
+	source, chain, err = keeper.FollowAliases(ctx, "n1")
+	require.Nil(t, err)
+	require.Equal(t, fmt.Sprintf("n%d", MaxAliasDepth+1), source)
+	require.Len(t, chain, MaxAliasDepth+1)
+	_, _, err = keeper.FollowAliases(ctx, "n0")
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "maximum depth")
+	_, err = resolve("n0")
+	require.NotNil(t, err)
+}
+
+func TestSuggestAlternatives(t *testing.T) {
+	ctx, keeper := CreateTestInput(t)
+	querier := NewQuerier(keeper)
+	price := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 1))
+	keeper.SetTLD(ctx, NewTLD("eth", addr1, price, []string{"nametoken"}, RegistrationModeOpen))
+	keeper.SetTLD(ctx, NewTLD("reg", addr1, price, []string{"nametoken"}, RegistrationModeRegistrar))
+	for _, name := range []string{"alice", "alice.eth", "alice1.eth", "bob.reg", "api.alice"} {
+		keeper.SetWhois(ctx, name, Whois{Owner: addr1, Price: price, Expires: 100})
+	}
+
+	available := func(name string) NameAvailability {
+		data := ModuleCdc.MustMarshalJSON(NewQueryAvailabilityParams([]string{name}))
+		bz, err := querier(ctx, []string{QueryAvailability}, abci.RequestQuery{Data: data})
+		require.Nil(t, err)
+		var res QueryResAvailability
+		ModuleCdc.MustUnmarshalJSON(bz, &res)
+		require.Len(t, res, 1)
+		return res[0]
+	}
+
+	require.Equal(t, []string{"alice1", "alice2", "aliceapp", "alicehq", "getalice"}, available("alice").Alternatives)
+	// affixes go on the leftmost label and owned alternatives are skipped
+	require.Equal(t, []string{"alice2.eth", "aliceapp.eth", "alicehq.eth", "getalice.eth", "myalice.eth"}, available("alice.eth").Alternatives)
+	// names only the registrar can register and subdomains are never suggested
+	require.Empty(t, available("bob.reg").Alternatives)
+	require.Empty(t, available("api.alice").Alternatives)
+	// free names need no alternatives
+	require.Empty(t, available("carol").Alternatives)
+}
+
+func TestQueryRecordsWithCNAME(t *testing.T) {
+	ctx, keeper := CreateTestInput(t)
+	querier := NewQuerier(keeper)
+	price := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 1))
+	keeper.SetWhois(ctx, "alice", Whois{Value: "hello", Owner: addr1, Price: price, Expires: 100})
+	keeper.SetRecords(ctx, "alice", Records{NewRecord("A", "192.0.2.1", 60)})
+	keeper.SetWhois(ctx, "www", Whois{Value: "hello", Owner: addr1, Price: price, Expires: 100})
+	keeper.SetRecords(ctx, "www", Records{NewRecord("CNAME", "alice.ns.", 60)})
+
+	records := func(path ...string) Records {
+		bz, err := querier(ctx, append([]string{QueryRecords}, path...), abci.RequestQuery{})
+		require.Nil(t, err)
+		var out QueryResRecords
+		ModuleCdc.MustUnmarshalJSON(bz, &out)
+		return out.Records
+	}
+
+	// the value is served as a TXT record next to other records
+	require.Equal(t, Records{NewRecord("TXT", "hello", 0), NewRecord("A", "192.0.2.1", 60)}, records("alice"))
+	require.Equal(t, Records{NewRecord("TXT", "hello", 0)}, records("alice", "TXT"))
+	// but not next to a CNAME
+	require.Equal(t, Records{NewRecord("CNAME", "alice.ns.", 60)}, records("www"))
+	require.Empty(t, records("www", "TXT"))
+}
+
+func TestQueryOwnerIndex(t *testing.T) {
+	ctx, keeper := CreateTestInput(t)
+	params := DefaultParams()
+	params.CommitRevealEnabled = false
+	keeper.SetParams(ctx, params)
+	handler := NewHandler(keeper)
+	querier := NewQuerier(keeper)
+	price := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 1))
+	fund(t, ctx, keeper, addr1, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 10)))
+	fund(t, ctx, keeper, addr2, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 10)))
+
+	owned := func(owner sdk.AccAddress) QueryResNames {
+		bz, err := querier(ctx, []string{QueryOwner, owner.String()}, abci.RequestQuery{})
+		require.Nil(t, err)
+		var names QueryResNames
+		ModuleCdc.MustUnmarshalJSON(bz, &names)
+		return names
+	}
+	require.Empty(t, owned(addr1))
+
+	for _, name := range []string{"bob", "alice"} {
+		res := handler(ctx, NewMsgBuyName(name, price, addr1, "secretsalt"))
+		require.True(t, res.IsOK(), res.Log)
+	}
+	require.Equal(t, QueryResNames{"alice", "bob"}, owned(addr1))
+
+	// buying a name moves it to the index of the buyer
+	res := handler(ctx, NewMsgBuyName("alice", sdk.NewCoins(sdk.NewInt64Coin("nametoken", 2)), addr2, "secretsalt"))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, QueryResNames{"bob"}, owned(addr1))
+	require.Equal(t, QueryResNames{"alice"}, owned(addr2))
+
+	// a pending transfer only moves the name once it is accepted
+	res = handler(ctx, NewMsgTransferName("bob", addr1, addr2, true))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, QueryResNames{"bob"}, owned(addr1))
+	res = handler(ctx, NewMsgAcceptTransfer("bob", addr2))
+	require.True(t, res.IsOK(), res.Log)
+	require.Empty(t, owned(addr1))
+	require.Equal(t, QueryResNames{"alice", "bob"}, owned(addr2))
+
+	// an immediate transfer moves it at once
+	res = handler(ctx, NewMsgTransferName("alice", addr2, addr1, false))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, QueryResNames{"alice"}, owned(addr1))
+	require.Equal(t, QueryResNames{"bob"}, owned(addr2))
+
+	_, err := querier(ctx, []string{QueryOwner, "not-an-address"}, abci.RequestQuery{})
+	require.NotNil(t, err)
+}
+
+func TestQueryNamesPagination(t *testing.T) {
+	ctx, keeper := CreateTestInput(t)
+	querier := NewQuerier(keeper)
+	price := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 1))
+	for _, name := range []string{"a", "b", "c", "d", "e", "acme", "api.acme", "www.acme"} {
+		keeper.SetWhois(ctx, name, Whois{Owner: addr1, Price: price, Expires: 100})
+	}
+
+	names := func(params QueryNamesParams) (QueryResNamesPage, sdk.Error) {
+		bz, err := querier(ctx, []string{QueryNames}, abci.RequestQuery{Data: ModuleCdc.MustMarshalJSON(params)})
+		var page QueryResNamesPage
+		if err == nil {
+			ModuleCdc.MustUnmarshalJSON(bz, &page)
+		}
+		return page, err
+	}
+
+	// counted pages
+	page, err := names(NewQueryNamesParams(1, 3, "", ""))
+	require.Nil(t, err)
+	require.Equal(t, QueryResNames{"a", "acme", "api.acme"}, page.Names)
+	require.Equal(t, "b", page.NextKey)
+	page, err = names(NewQueryNamesParams(3, 3, "", ""))
+	require.Nil(t, err)
+	require.Equal(t, QueryResNames{"e", "www.acme"}, page.Names)
+	require.Empty(t, page.NextKey)
+	// a page that exactly reaches the end has no next key
+	page, err = names(NewQueryNamesParams(2, 4, "", ""))
+	require.Nil(t, err)
+	require.Equal(t, QueryResNames{"c", "d", "e", "www.acme"}, page.Names)
+	require.Empty(t, page.NextKey)
+	page, err = names(NewQueryNamesParams(4, 3, "", ""))
+	require.Nil(t, err)
+	require.Empty(t, page.Names)
+	require.Empty(t, page.NextKey)
+
+	// cursor pages ignore the page number, and the start key need not be a name
+	page, err = names(NewQueryNamesParams(0, 2, "b", ""))
+	require.Nil(t, err)
+	require.Equal(t, QueryResNames{"b", "c"}, page.Names)
+	require.Equal(t, "d", page.NextKey)
+	page, err = names(NewQueryNamesParams(5, 2, "bz", ""))
+	require.Nil(t, err)
+	require.Equal(t, QueryResNames{"c", "d"}, page.Names)
+	page, err = names(NewQueryNamesParams(1, 2, "zz", ""))
+	require.Nil(t, err)
+	require.Empty(t, page.Names)
+	require.Empty(t, page.NextKey)
+
+	// children of a parent are paged the same way
+	page, err = names(NewQueryNamesParams(1, 1, "", "acme"))
+	require.Nil(t, err)
+	require.Equal(t, QueryResNames{"api.acme"}, page.Names)
+	require.Equal(t, "www.acme", page.NextKey)
+	page, err = names(NewQueryNamesParams(1, 1, page.NextKey, "acme"))
+	require.Nil(t, err)
+	require.Equal(t, QueryResNames{"www.acme"}, page.Names)
+	require.Empty(t, page.NextKey)
+
+	// invalid pages and limits
+	for _, params := range []QueryNamesParams{
+		NewQueryNamesParams(0, 3, "", ""),
+		NewQueryNamesParams(-1, 3, "", ""),
+		NewQueryNamesParams(1, 0, "", ""),
+		NewQueryNamesParams(1, MaxNamesLimit+1, "", ""),
+		NewQueryNamesParams(1, -1, "b", ""),
+	} {
+		_, err = names(params)
+		require.NotNil(t, err, "%+v", params)
+	}
+
+	// without request data the first page of the default size is returned
+	bz, err := querier(ctx, []string{QueryNames}, abci.RequestQuery{})
+	require.Nil(t, err)
+	ModuleCdc.MustUnmarshalJSON(bz, &page)
+	require.Len(t, page.Names, 8)
+}
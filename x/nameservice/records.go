@@ -0,0 +1,67 @@
+package nameservice
+
+// 类型化解析记录：每个域名可以拥有一组 A、AAAA、CNAME、MX、TXT、SRV 记录，
+// 与 Whois 分开存储，Whois.Value 仍作为默认的 TXT 记录。
+// 内容哈希（EIP-1577）指向域名在 IPFS、Swarm 等内容寻址存储上发布的内容，同样单独存储。
+import (
+	"github.com/jerryma0912/Cosmos-sdk-tutorial/x/nameservice/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GetRecords - returns the record set of a name
+func (k Keeper) GetRecords(ctx sdk.Context, name string) Records {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetRecordsKey(name))
+	if bz == nil {
+		return Records{}
+	}
+	var records Records
+	k.cdc.MustUnmarshalBinaryBare(bz, &records)
+	return records
+}
+
+// SetRecords - replaces the record set of a name, removing it when empty
+func (k Keeper) SetRecords(ctx sdk.Context, name string, records Records) {
+	store := ctx.KVStore(k.storeKey)
+	if len(records) == 0 {
+		store.Delete(types.GetRecordsKey(name))
+		return
+	}
+	store.Set(types.GetRecordsKey(name), k.cdc.MustMarshalBinaryBare(records))
+}
+
+// GetRecordsIterator - returns an iterator over the record sets of all names
+func (k Keeper) GetRecordsIterator(ctx sdk.Context) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.RecordsKeyPrefix)
+}
+
+// GetContentHash - returns the content hash of a name, or nil
+func (k Keeper) GetContentHash(ctx sdk.Context, name string) ContentHash {
+	store := ctx.KVStore(k.storeKey)
+	return ContentHash(store.Get(types.GetContentHashKey(name)))
+}
+
+// SetContentHash - sets the content hash of a name, removing it when empty
+func (k Keeper) SetContentHash(ctx sdk.Context, name string, contentHash ContentHash) {
+	store := ctx.KVStore(k.storeKey)
+	if len(contentHash) == 0 {
+		store.Delete(types.GetContentHashKey(name))
+		return
+	}
+	store.Set(types.GetContentHashKey(name), contentHash)
+}
+
+// ResolveRecords - returns the records of a name of the given type, or of all types if
+// recordType is empty. A non-empty Whois.Value is returned as the first TXT record, unless
+// the name has a CNAME record, which cannot coexist with other data (RFC 1034 3.6.2).
+func (k Keeper) ResolveRecords(ctx sdk.Context, name string, recordType string) Records {
+	records := Records{}
+	stored := k.GetRecords(ctx, name)
+	hasCNAME := len(stored.Filter(types.RecordTypeCNAME)) > 0
+	if value := k.ResolveName(ctx, name); value != "" && !hasCNAME && (recordType == "" || recordType == types.RecordTypeTXT) {
+		records = append(records, types.NewRecord(types.RecordTypeTXT, value, 0))
+	}
+	return append(records, stored.Filter(recordType)...)
+}
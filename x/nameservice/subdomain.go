@@ -0,0 +1,56 @@
+package nameservice
+
+// 子域名：父域名的所有者可以为自己或其他地址创建子域名（如 acme 的所有者创建 api.acme），
+// 子域名不能通过 MsgBuyName 或拍卖获得，与父域名一同到期。
+import (
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	"github.com/jerryma0912/Cosmos-sdk-tutorial/x/nameservice/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GetChildrenIterator - returns an iterator over the direct subdomains of a name,
+// in which the keys are the names of the subdomains
+func (k Keeper) GetChildrenIterator(ctx sdk.Context, parent string) sdk.Iterator {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.GetChildIndexPrefix(parent))
+	return sdk.KVStorePrefixIterator(store, nil)
+}
+
+// GetChildrenIteratorFrom - returns an iterator over the direct subdomains of a name
+// starting at the given subdomain (inclusive)
+func (k Keeper) GetChildrenIteratorFrom(ctx sdk.Context, parent string, start string) sdk.Iterator {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.GetChildIndexPrefix(parent))
+	return store.Iterator([]byte(start), nil)
+}
+
+// GetChildren - returns the names of the direct subdomains of a name
+func (k Keeper) GetChildren(ctx sdk.Context, parent string) []string {
+	iterator := k.GetChildrenIterator(ctx, parent)
+	defer iterator.Close()
+	var children []string
+	for ; iterator.Valid(); iterator.Next() {
+		children = append(children, string(iterator.Key()))
+	}
+	return children
+}
+
+// ResolveSource - returns the name whose value and records answer for name, following
+// DNS wildcard rules (RFC 4592): a name that exists answers for itself, otherwise the
+// wildcard below its closest existing ancestor answers, if there is one. wildcard
+// reports whether the answer comes from a wildcard.
+func (k Keeper) ResolveSource(ctx sdk.Context, name string) (source string, wildcard bool) {
+	if k.HasOwner(ctx, name) || types.IsWildcard(name) {
+		return name, false
+	}
+	for ancestor, ok := types.ParentName(name); ok; ancestor, ok = types.ParentName(ancestor) {
+		if !k.HasOwner(ctx, ancestor) {
+			continue
+		}
+		// 最近的已存在祖先决定是否有通配符，更高层的通配符不再适用
+		if candidate := types.SubdomainName(types.WildcardLabel, ancestor); k.HasOwner(ctx, candidate) {
+			return candidate, true
+		}
+		break
+	}
+	return name, false
+}
@@ -0,0 +1,80 @@
+package nameservice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestBurnFusesAfterCannotReclaim(t *testing.T) {
+	ctx, keeper := CreateTestInput(t)
+	handler := NewHandler(keeper)
+	price := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 1))
+	keeper.SetWhois(ctx, "acme", Whois{Owner: addr1, Price: price, Expires: 100})
+
+	res := handler(ctx, NewMsgCreateSubdomain("api.acme", addr2, addr1))
+	require.True(t, res.IsOK(), res.Log)
+
+	// the subdomain owner cannot burn fuses while the parent is in control
+	res = handler(ctx, NewMsgBurnFuses("api.acme", FuseCannotTransfer, addr2))
+	require.False(t, res.IsOK())
+
+	res = handler(ctx, NewMsgBurnFuses("api.acme", FuseCannotReclaim, addr1))
+	require.True(t, res.IsOK(), res.Log)
+
+	// once cannot-reclaim is burned the parent can no longer burn fuses
+	res = handler(ctx, NewMsgBurnFuses("api.acme", FuseCannotSetRecords, addr1))
+	require.False(t, res.IsOK())
+	require.False(t, keeper.GetWhois(ctx, "api.acme").Fuses.Has(FuseCannotSetRecords))
+
+	// but the subdomain owner can
+	res = handler(ctx, NewMsgBurnFuses("api.acme", FuseCannotTransfer, addr2))
+	require.True(t, res.IsOK(), res.Log)
+	require.True(t, keeper.GetWhois(ctx, "api.acme").Fuses.Has(FuseCannotReclaim|FuseCannotTransfer))
+}
+
+func TestReassignSubdomain(t *testing.T) {
+	ctx, keeper := CreateTestInput(t)
+	handler := NewHandler(keeper)
+	price := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 1))
+	keeper.SetWhois(ctx, "acme", Whois{Owner: addr1, Price: price, Expires: 100})
+
+	res := handler(ctx, NewMsgCreateSubdomain("api.acme", addr2, addr1))
+	require.True(t, res.IsOK(), res.Log)
+	res = handler(ctx, NewMsgBurnFuses("api.acme", FuseCannotCreateChildren, addr1))
+	require.True(t, res.IsOK(), res.Log)
+	whois := keeper.GetWhois(ctx, "api.acme")
+	whois.Value, whois.Alias, whois.Price = "192.0.2.1", "acme", sdk.NewCoins(sdk.NewInt64Coin("nametoken", 50))
+	keeper.SetWhois(ctx, "api.acme", whois)
+	keeper.SetRecords(ctx, "api.acme", Records{NewRecord("A", "192.0.2.1", 60)})
+	keeper.SetText(ctx, "api.acme", "url", "https://example.com")
+	keeper.SetAddress(ctx, "api.acme", 60, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	contentHash, err := ParseContentHash("ipfs://QmRAQB6YaCyidP37UdDnjFY5vQuiBrcqdyoW1CuDgwxkD4")
+	require.NoError(t, err)
+	keeper.SetContentHash(ctx, "api.acme", contentHash)
+
+	// the parent re-creating the subdomain for the same owner keeps its data
+	res = handler(ctx, NewMsgCreateSubdomain("api.acme", addr2, addr1))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, "192.0.2.1", keeper.GetWhois(ctx, "api.acme").Value)
+	require.Len(t, keeper.GetRecords(ctx, "api.acme"), 1)
+
+	// a new owner starts from an empty whois, only the fuses carry over
+	res = handler(ctx, NewMsgCreateSubdomain("api.acme", addr3, addr1))
+	require.True(t, res.IsOK(), res.Log)
+	whois = keeper.GetWhois(ctx, "api.acme")
+	require.Equal(t, addr3, whois.Owner)
+	require.Equal(t, int64(100), whois.Expires)
+	require.Equal(t, FuseCannotCreateChildren, whois.Fuses)
+	require.Empty(t, whois.Value)
+	require.Empty(t, whois.Alias)
+	require.Equal(t, Gateway{}, whois.Gateway)
+	require.Equal(t, keeper.GetRegistry(ctx, "api.acme").MinPrice, whois.Price)
+	require.Empty(t, keeper.GetRecords(ctx, "api.acme"))
+	require.Empty(t, keeper.GetTexts(ctx, "api.acme"))
+	require.Empty(t, keeper.GetAddresses(ctx, "api.acme"))
+	require.Empty(t, keeper.GetContentHash(ctx, "api.acme"))
+	require.Equal(t, []string{"api.acme"}, keeper.GetChildren(ctx, "acme"))
+}
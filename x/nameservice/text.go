@@ -0,0 +1,51 @@
+package nameservice
+
+// 文本记录：域名所有者可以为域名设置任意键的文本记录（avatar、url、email 等），
+// 组成域名的公开资料。与 Whois 分开存储，域名被释放时一并删除。
+import (
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	"github.com/jerryma0912/Cosmos-sdk-tutorial/x/nameservice/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GetText - returns the text record of a name with the given key, or an empty string
+func (k Keeper) GetText(ctx sdk.Context, name string, key string) string {
+	store := ctx.KVStore(k.storeKey)
+	return string(store.Get(types.GetTextKey(name, key)))
+}
+
+// SetText - sets the text record of a name with the given key, removing it when empty
+func (k Keeper) SetText(ctx sdk.Context, name string, key string, value string) {
+	store := ctx.KVStore(k.storeKey)
+	if value == "" {
+		store.Delete(types.GetTextKey(name, key))
+		return
+	}
+	store.Set(types.GetTextKey(name, key), []byte(value))
+}
+
+// GetTextsIterator - returns an iterator over the text records of a name, in which
+// the keys are the text keys and the values are the texts
+func (k Keeper) GetTextsIterator(ctx sdk.Context, name string) sdk.Iterator {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.GetTextsPrefix(name))
+	return sdk.KVStorePrefixIterator(store, nil)
+}
+
+// GetTexts - returns all text records of a name, ordered by key
+func (k Keeper) GetTexts(ctx sdk.Context, name string) TextRecords {
+	texts := TextRecords{}
+	iterator := k.GetTextsIterator(ctx, name)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		texts = append(texts, TextRecord{Key: string(iterator.Key()), Value: string(iterator.Value())})
+	}
+	return texts
+}
+
+// DeleteTexts - removes all text records of a name
+func (k Keeper) DeleteTexts(ctx sdk.Context, name string) {
+	for _, text := range k.GetTexts(ctx, name) {
+		k.SetText(ctx, name, text.Key, "")
+	}
+}
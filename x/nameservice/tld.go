@@ -0,0 +1,67 @@
+package nameservice
+
+// 顶级域名（TLD）注册表：每个 TLD 有自己的注册商、最低价格、允许的币种和注册模式，
+// TLD 下的二级域名（如 alice.dev）按该 TLD 的规则注册，付款归注册商所有。
+// 不属于任何 TLD 的顶级域名（如 alice）使用由模块参数得出的根命名空间规则。
+import (
+	"github.com/jerryma0912/Cosmos-sdk-tutorial/x/nameservice/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GetTLD - gets a TLD, if it exists
+func (k Keeper) GetTLD(ctx sdk.Context, name string) (tld TLD, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetTLDKey(name))
+	if bz == nil {
+		return tld, false
+	}
+	k.cdc.MustUnmarshalBinaryBare(bz, &tld)
+	return tld, true
+}
+
+// HasTLD - returns whether a TLD exists
+func (k Keeper) HasTLD(ctx sdk.Context, name string) bool {
+	return ctx.KVStore(k.storeKey).Has(types.GetTLDKey(name))
+}
+
+// SetTLD - creates or updates a TLD
+func (k Keeper) SetTLD(ctx sdk.Context, tld TLD) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetTLDKey(tld.Name), k.cdc.MustMarshalBinaryBare(tld))
+}
+
+// GetTLDsIterator - returns an iterator over all TLDs
+func (k Keeper) GetTLDsIterator(ctx sdk.Context) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.TLDKeyPrefix)
+}
+
+// GetRegistry - returns the TLD a name is registered under: the TLD of its parent,
+// or the root namespace derived from the module parameters for top-level names
+func (k Keeper) GetRegistry(ctx sdk.Context, name string) TLD {
+	if parent, ok := types.ParentName(name); ok {
+		if tld, found := k.GetTLD(ctx, parent); found {
+			return tld
+		}
+	}
+	return k.GetParams(ctx).RootTLD()
+}
+
+// IsRegistrable - returns whether a name is registered under a registry, i.e. it is
+// either a top-level name or directly below a TLD, rather than a subdomain of a name
+func (k Keeper) IsRegistrable(ctx sdk.Context, name string) bool {
+	parent, ok := types.ParentName(name)
+	return !ok || k.HasTLD(ctx, parent)
+}
+
+// CollectPayment - takes a payment for a name from payer. Payments for names below a
+// TLD go to its registrar, payments in the root namespace are burned.
+func (k Keeper) CollectPayment(ctx sdk.Context, name string, payer sdk.AccAddress, amount sdk.Coins) sdk.Error {
+	registry := k.GetRegistry(ctx, name)
+	if registry.IsRoot() {
+		_, err := k.coinKeeper.SubtractCoins(ctx, payer, amount)
+		return err
+	}
+	return k.coinKeeper.SendCoins(ctx, payer, registry.Registrar, amount)
+}
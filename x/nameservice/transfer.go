@@ -0,0 +1,38 @@
+package nameservice
+
+// 两步转让：所有者发起转让后，域名仍归所有者所有，直到接收者接受或所有者取消。
+// 域名以其他方式更换所有者（购买、到期释放）时，待确认的转让随之失效。
+import (
+	"github.com/jerryma0912/Cosmos-sdk-tutorial/x/nameservice/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GetPendingTransfer - gets the pending transfer of a name, if any
+func (k Keeper) GetPendingTransfer(ctx sdk.Context, name string) (transfer PendingTransfer, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetTransferKey(name))
+	if bz == nil {
+		return transfer, false
+	}
+	k.cdc.MustUnmarshalBinaryBare(bz, &transfer)
+	return transfer, true
+}
+
+// SetPendingTransfer - stores a pending transfer, replacing any earlier one for the same name
+func (k Keeper) SetPendingTransfer(ctx sdk.Context, transfer PendingTransfer) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetTransferKey(transfer.Name), k.cdc.MustMarshalBinaryBare(transfer))
+}
+
+// DeletePendingTransfer - removes the pending transfer of a name
+func (k Keeper) DeletePendingTransfer(ctx sdk.Context, name string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetTransferKey(name))
+}
+
+// GetPendingTransfersIterator - returns an iterator over all pending transfers
+func (k Keeper) GetPendingTransfersIterator(ctx sdk.Context) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.TransferKeyPrefix)
+}
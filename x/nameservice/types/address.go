@@ -0,0 +1,167 @@
+package types
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/btcsuite/btcutil/bech32"
+	"golang.org/x/crypto/sha3"
+)
+
+// SLIP-44 coin types of some well known chains, see
+// https://github.com/satoshilabs/slips/blob/master/slip-0044.md
+const (
+	CoinTypeBTC  uint32 = 0
+	CoinTypeLTC  uint32 = 2
+	CoinTypeDOGE uint32 = 3
+	CoinTypeETH  uint32 = 60
+	CoinTypeETC  uint32 = 61
+	CoinTypeATOM uint32 = 118
+	CoinTypeTRX  uint32 = 195
+	CoinTypeSOL  uint32 = 501
+
+	// EVM 链按 ENSIP-11 使用 0x80000000 | chainId 作为币种
+	// CoinTypeEVMFlag marks the coin types of EVM chains derived from their chain id (ENSIP-11)
+	CoinTypeEVMFlag uint32 = 0x80000000
+)
+
+const (
+	// MaxAddressesPerName is the maximum number of address records a single name can hold
+	MaxAddressesPerName = 32
+
+	// MaxAddressLength is the maximum length of an address record
+	MaxAddressLength = 128
+)
+
+// AddressRecord is the address of a name on the chain identified by a SLIP-44 coin type
+type AddressRecord struct {
+	CoinType uint32 `json:"coin_type"`
+	Address  string `json:"address"`
+}
+
+// implement fmt.Stringer
+func (a AddressRecord) String() string {
+	return fmt.Sprintf("%d\t%s", a.CoinType, a.Address)
+}
+
+// AddressRecords is the list of addresses of a name, ordered by coin type
+type AddressRecords []AddressRecord
+
+// implement fmt.Stringer
+func (as AddressRecords) String() string {
+	lines := make([]string, len(as))
+	for i, a := range as {
+		lines[i] = a.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ValidateAddress checks that addr is well formed for the chain of the coin type.
+// Chains without a known format accept bech32, hex EVM, base58check or base58 public key addresses.
+func ValidateAddress(coinType uint32, addr string) error {
+	if len(addr) == 0 {
+		return fmt.Errorf("address cannot be empty")
+	}
+	if len(addr) > MaxAddressLength {
+		return fmt.Errorf("address cannot be longer than %d bytes", MaxAddressLength)
+	}
+	var err error
+	switch {
+	case coinType == CoinTypeETH || coinType == CoinTypeETC || coinType&CoinTypeEVMFlag != 0:
+		err = ValidateHexAddress(addr)
+	case coinType == CoinTypeBTC || coinType == CoinTypeLTC:
+		// legacy P2PKH/P2SH or segwit addresses
+		if err = ValidateBase58CheckAddress(addr); err != nil && ValidateBech32Address(addr) == nil {
+			err = nil
+		}
+	case coinType == CoinTypeDOGE || coinType == CoinTypeTRX:
+		err = ValidateBase58CheckAddress(addr)
+	case coinType == CoinTypeATOM:
+		// 所有 Cosmos 链共用 118，前缀各不相同
+		err = ValidateBech32Address(addr)
+	case coinType == CoinTypeSOL:
+		if len(base58.Decode(addr)) != 32 {
+			err = fmt.Errorf("invalid base58 public key %q", addr)
+		}
+	default:
+		if ValidateBech32Address(addr) != nil && ValidateHexAddress(addr) != nil && ValidateBase58Address(addr) != nil {
+			err = fmt.Errorf("address %q is neither bech32, hex, base58check nor a base58 public key", addr)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("invalid address for coin type %d: %s", coinType, err)
+	}
+	return nil
+}
+
+// ValidateBech32Address - checks that addr is a bech32 string with a valid checksum, of any human readable part
+func ValidateBech32Address(addr string) error {
+	if _, _, err := bech32.Decode(addr); err != nil {
+		return fmt.Errorf("invalid bech32 address %q: %s", addr, err)
+	}
+	return nil
+}
+
+// ValidateHexAddress - checks that addr is a 0x prefixed 20 byte hex address. Mixed case
+// addresses must carry a valid EIP-55 checksum.
+func ValidateHexAddress(addr string) error {
+	if !strings.HasPrefix(addr, "0x") || len(addr) != 42 {
+		return fmt.Errorf("hex address %q must be 0x followed by 40 hex digits", addr)
+	}
+	digits := addr[2:]
+	if _, err := hex.DecodeString(digits); err != nil {
+		return fmt.Errorf("invalid hex address %q", addr)
+	}
+	if digits == strings.ToLower(digits) || digits == strings.ToUpper(digits) {
+		return nil
+	}
+	if addr != ToChecksumHexAddress(addr) {
+		return fmt.Errorf("invalid EIP-55 checksum of hex address %q", addr)
+	}
+	return nil
+}
+
+// ToChecksumHexAddress - returns the EIP-55 mixed case form of a hex address
+func ToChecksumHexAddress(addr string) string {
+	digits := strings.ToLower(strings.TrimPrefix(addr, "0x"))
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write([]byte(digits)) // nolint: errcheck
+	sum := hash.Sum(nil)
+	out := []byte(digits)
+	for i, c := range out {
+		// 哈希对应的半字节 >= 8 时字母大写
+		nibble := sum[i/2] >> 4
+		if i%2 == 1 {
+			nibble = sum[i/2] & 0x0f
+		}
+		if c >= 'a' && nibble >= 8 {
+			out[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(out)
+}
+
+// ValidateBase58Address - checks that addr is either a base58check address or the base58
+// encoding of a 32 byte public key. Other base58 strings are rejected, as they carry
+// neither a checksum nor a known length.
+func ValidateBase58Address(addr string) error {
+	if ValidateBase58CheckAddress(addr) == nil || len(base58.Decode(addr)) == 32 {
+		return nil
+	}
+	return fmt.Errorf("address %q is neither base58check nor a base58 public key", addr)
+}
+
+// ValidateBase58CheckAddress - checks that addr is a base58 string with a valid
+// version byte and checksum, as used by Bitcoin and its forks
+func ValidateBase58CheckAddress(addr string) error {
+	payload, _, err := base58.CheckDecode(addr)
+	if err != nil {
+		return fmt.Errorf("invalid base58check address %q: %s", addr, err)
+	}
+	if len(payload) != 20 {
+		return fmt.Errorf("invalid base58check address %q: payload must be 20 bytes", addr)
+	}
+	return nil
+}
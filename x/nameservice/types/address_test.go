@@ -0,0 +1,81 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAddress(t *testing.T) {
+	const (
+		eth    = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+		btc    = "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2"
+		segwit = "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq"
+		atom   = "cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xu"
+		sol    = "So11111111111111111111111111111111111111112"
+	)
+	payload := make([]byte, 20)
+	tests := []struct {
+		name     string
+		coinType uint32
+		addr     string
+		valid    bool
+	}{
+		{"btc p2pkh", CoinTypeBTC, btc, true},
+		{"btc segwit", CoinTypeBTC, segwit, true},
+		{"btc bad checksum", CoinTypeBTC, btc[:len(btc)-1] + "3", false},
+		{"btc bad bech32 checksum", CoinTypeBTC, segwit[:len(segwit)-1] + "p", false},
+		{"ltc p2pkh", CoinTypeLTC, base58.CheckEncode(payload, 0x30), true},
+		{"doge p2pkh", CoinTypeDOGE, base58.CheckEncode(payload, 0x1e), true},
+		{"doge short payload", CoinTypeDOGE, base58.CheckEncode(payload[1:], 0x1e), false},
+		{"doge long payload", CoinTypeDOGE, base58.CheckEncode(append(payload, 0), 0x1e), false},
+		{"doge segwit", CoinTypeDOGE, segwit, false},
+		{"trx", CoinTypeTRX, base58.CheckEncode(payload, 0x41), true},
+
+		// EVM 链的地址
+		{"eth checksummed", CoinTypeETH, eth, true},
+		{"eth lower case", CoinTypeETH, strings.ToLower(eth), true},
+		{"eth upper case", CoinTypeETH, "0x" + strings.ToUpper(eth[2:]), true},
+		{"eth bad EIP-55 checksum", CoinTypeETH, "0x5AAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", false},
+		{"eth short", CoinTypeETH, eth[:40], false},
+		{"eth long", CoinTypeETH, eth + "00", false},
+		{"eth no 0x", CoinTypeETH, eth[2:] + "00", false},
+		{"eth not hex", CoinTypeETH, "0x" + strings.Repeat("g", 40), false},
+		{"etc", CoinTypeETC, eth, true},
+		{"evm chain", CoinTypeEVMFlag | 10, eth, true},
+		{"evm chain bad checksum", CoinTypeEVMFlag | 10, "0x5AAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", false},
+
+		{"atom", CoinTypeATOM, atom, true},
+		{"atom bad checksum", CoinTypeATOM, atom[:len(atom)-1] + "a", false},
+		{"atom hex", CoinTypeATOM, eth, false},
+
+		{"sol", CoinTypeSOL, sol, true},
+		{"sol short key", CoinTypeSOL, base58.Encode(make([]byte, 31)), false},
+		{"sol not base58", CoinTypeSOL, "0OIl" + sol[4:], false},
+
+		// 未知币种接受带校验的格式
+		{"unknown bech32", 9999, atom, true},
+		{"unknown hex", 9999, eth, true},
+		{"unknown base58check", 9999, btc, true},
+		{"unknown base58 key", 9999, sol, true},
+		{"unknown bad EIP-55 checksum", 9999, "0x5AAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", false},
+		{"unknown base58 bad checksum", 9999, btc[:len(btc)-1] + "3", false},
+		{"unknown short base58", 9999, "abc", false},
+		{"unknown base58 long payload", 9999, base58.CheckEncode(make([]byte, 21), 0), false},
+
+		{"empty", CoinTypeETH, "", false},
+		{"too long", 9999, strings.Repeat("1", MaxAddressLength+1), false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateAddress(tc.coinType, tc.addr)
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
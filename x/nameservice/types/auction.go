@@ -0,0 +1,104 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+)
+
+// Auction holds the state of a sealed-bid (Vickrey) auction for an unowned name.
+// The highest revealed bidder wins the name at the second-highest revealed price.
+type Auction struct {
+	Name          string         `json:"name"`
+	CommitEnd     int64          `json:"commit_end"` // last height at which bids can be committed
+	RevealEnd     int64          `json:"reveal_end"` // last height at which bids can be revealed
+	Bids          int64          `json:"bids"`       // number of committed bids
+	HighestBidder sdk.AccAddress `json:"highest_bidder"`
+	HighestBid    sdk.Coin       `json:"highest_bid"`
+	SecondBid     sdk.Coin       `json:"second_bid"`
+	// 拍卖开始时记录最低价格及其币种，之后 TLD 的修改不影响正在进行的拍卖
+	MinPrice sdk.Coin `json:"min_price"` // lowest accepted bid, in the only denomination accepted
+}
+
+// NewAuction returns an auction for a name whose commit window opens at the given height
+func NewAuction(name string, height int64, commitPeriod int64, revealPeriod int64, minPrice sdk.Coin) Auction {
+	return Auction{
+		Name:      name,
+		CommitEnd: height + commitPeriod,
+		RevealEnd: height + commitPeriod + revealPeriod,
+		MinPrice:  minPrice,
+	}
+}
+
+// Auction phases as reported by Auction.Phase
+const (
+	AuctionPhaseCommit = "commit"
+	AuctionPhaseReveal = "reveal"
+	AuctionPhaseClosed = "closed"
+)
+
+// Phase returns the phase the auction is in at the given height
+func (a Auction) Phase(height int64) string {
+	switch {
+	case height <= a.CommitEnd:
+		return AuctionPhaseCommit
+	case height <= a.RevealEnd:
+		return AuctionPhaseReveal
+	default:
+		return AuctionPhaseClosed
+	}
+}
+
+// Price returns what the winner pays: the second-highest bid, but never less than the minimum price
+func (a Auction) Price() sdk.Coin {
+	if a.SecondBid.Denom == a.MinPrice.Denom && a.SecondBid.IsGTE(a.MinPrice) {
+		return a.SecondBid
+	}
+	return a.MinPrice
+}
+
+// implement fmt.Stringer
+func (a Auction) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`Name: %s
+Commit End: %d
+Reveal End: %d
+Bids: %d
+Highest Bidder: %s
+Highest Bid: %s
+Second Bid: %s
+Min Price: %s`, a.Name, a.CommitEnd, a.RevealEnd, a.Bids, a.HighestBidder, a.HighestBid, a.SecondBid, a.MinPrice))
+}
+
+// Bid is a sealed bid committed to an auction. The deposit is held in escrow
+// until the bid loses or the auction is settled.
+type Bid struct {
+	Name     string         `json:"name"`
+	Bidder   sdk.AccAddress `json:"bidder"`
+	BidHash  []byte         `json:"bid_hash"`
+	Deposit  sdk.Coin       `json:"deposit"`
+	Revealed bool           `json:"revealed"`
+}
+
+// implement fmt.Stringer
+func (b Bid) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`Name: %s
+Bidder: %s
+Bid Hash: %X
+Deposit: %s
+Revealed: %t`, b.Name, b.Bidder, b.BidHash, b.Deposit, b.Revealed))
+}
+
+// GetBidHash returns the hash a bidder commits to for a sealed bid on a name. The hash
+// binds the bidder, like GetNameCommitment binds the buyer, so that a commitment
+// copied from another bidder cannot be revealed by the copier.
+func GetBidHash(name string, bidder sdk.AccAddress, bid sdk.Coin, salt string) []byte {
+	return tmhash.Sum([]byte(fmt.Sprintf("%s\x00%s\x00%s\x00%s", name, bidder, bid, salt)))
+}
+
+// VerifyBidHash checks that a revealed bid of a bidder matches the committed hash
+func VerifyBidHash(hash []byte, name string, bidder sdk.AccAddress, bid sdk.Coin, salt string) bool {
+	return bytes.Equal(hash, GetBidHash(name, bidder, bid, salt))
+}
@@ -18,4 +18,23 @@ func init() {
 func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgSetName{}, "nameservice/SetName", nil)
 	cdc.RegisterConcrete(MsgBuyName{}, "nameservice/BuyName", nil)
+	cdc.RegisterConcrete(MsgRenewName{}, "nameservice/RenewName", nil)
+	cdc.RegisterConcrete(MsgCommitBid{}, "nameservice/CommitBid", nil)
+	cdc.RegisterConcrete(MsgRevealBid{}, "nameservice/RevealBid", nil)
+	cdc.RegisterConcrete(MsgCommitName{}, "nameservice/CommitName", nil)
+	cdc.RegisterConcrete(MsgTransferName{}, "nameservice/TransferName", nil)
+	cdc.RegisterConcrete(MsgAcceptTransfer{}, "nameservice/AcceptTransfer", nil)
+	cdc.RegisterConcrete(MsgCancelTransfer{}, "nameservice/CancelTransfer", nil)
+	cdc.RegisterConcrete(MsgSetRecord{}, "nameservice/SetRecord", nil)
+	cdc.RegisterConcrete(MsgDeleteRecord{}, "nameservice/DeleteRecord", nil)
+	cdc.RegisterConcrete(MsgSetPrimaryName{}, "nameservice/SetPrimaryName", nil)
+	cdc.RegisterConcrete(MsgCreateSubdomain{}, "nameservice/CreateSubdomain", nil)
+	cdc.RegisterConcrete(MsgBurnFuses{}, "nameservice/BurnFuses", nil)
+	cdc.RegisterConcrete(MsgSetTLD{}, "nameservice/SetTLD", nil)
+	cdc.RegisterConcrete(MsgSetAlias{}, "nameservice/SetAlias", nil)
+	cdc.RegisterConcrete(MsgSetAddress{}, "nameservice/SetAddress", nil)
+	cdc.RegisterConcrete(MsgSetText{}, "nameservice/SetText", nil)
+	cdc.RegisterConcrete(MsgSetContentHash{}, "nameservice/SetContentHash", nil)
+	cdc.RegisterConcrete(MsgSetGateway{}, "nameservice/SetGateway", nil)
+	cdc.RegisterConcrete(CreateTLDProposal{}, "nameservice/CreateTLDProposal", nil)
 }
@@ -0,0 +1,51 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+)
+
+// MinSaltLength is the minimum length of the salt of a name commitment. Short salts let
+// anyone watching the mempool guess the committed name by trying candidate names.
+const MinSaltLength = 8
+
+// Commitment is a salted hash of a name and its future buyer, committed
+// ahead of a MsgBuyName so that the name is not visible in the mempool
+type Commitment struct {
+	Committer sdk.AccAddress `json:"committer"`
+	Hash      []byte         `json:"hash"`
+	Height    int64          `json:"height"` // height at which the commitment was made
+}
+
+// IsMature - returns whether the commitment is old enough to be revealed at the given height
+func (c Commitment) IsMature(height int64, minAge int64) bool {
+	return height-c.Height >= minAge
+}
+
+// IsStale - returns whether the commitment is too old to be revealed at the given height
+func (c Commitment) IsStale(height int64, maxAge int64) bool {
+	return height-c.Height > maxAge
+}
+
+// implement fmt.Stringer
+func (c Commitment) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`Committer: %s
+Hash: %X
+Height: %d`, c.Committer, c.Hash, c.Height))
+}
+
+// GetNameCommitment returns the hash a buyer commits to before buying a name
+func GetNameCommitment(name string, buyer sdk.AccAddress, salt string) []byte {
+	return tmhash.Sum([]byte(fmt.Sprintf("%s\x00%s\x00%s", name, buyer, salt)))
+}
+
+// ValidateSalt checks that a commitment salt is long enough to hide the committed name
+func ValidateSalt(salt string) error {
+	if len(salt) < MinSaltLength {
+		return fmt.Errorf("salt must be at least %d characters long", MinSaltLength)
+	}
+	return nil
+}
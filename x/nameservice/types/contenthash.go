@@ -0,0 +1,351 @@
+package types
+
+import (
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/btcsuite/btcutil/base58"
+)
+
+// Protocol codes of the content hash namespaces, see
+// https://github.com/multiformats/multicodec/blob/master/table.csv
+const (
+	ContentHashIPFS   uint64 = 0xe3
+	ContentHashSwarm  uint64 = 0xe4
+	ContentHashIPNS   uint64 = 0xe5
+	ContentHashOnion3 uint64 = 0x01bd
+)
+
+// URI schemes of the content hash namespaces
+var contentHashSchemes = map[uint64]string{
+	ContentHashIPFS:   "ipfs",
+	ContentHashSwarm:  "bzz",
+	ContentHashIPNS:   "ipns",
+	ContentHashOnion3: "onion3",
+}
+
+// multicodec 中内容类型的编码
+const (
+	codecRaw           uint64 = 0x55
+	codecDagPB         uint64 = 0x70
+	codecDagCBOR       uint64 = 0x71
+	codecLibp2pKey     uint64 = 0x72
+	codecSwarmManifest uint64 = 0xfa
+	codecDagJSON       uint64 = 0x0129
+)
+
+// content codecs accepted in CIDv1
+var cidCodecs = map[uint64]bool{
+	codecRaw:           true,
+	codecDagPB:         true,
+	codecDagCBOR:       true,
+	codecLibp2pKey:     true,
+	codecSwarmManifest: true,
+	codecDagJSON:       true,
+}
+
+// multihash 的哈希函数编码及其摘要长度
+const (
+	hashIdentity  uint64 = 0x00
+	hashSHA2256   uint64 = 0x12
+	hashKeccak256 uint64 = 0x1b
+)
+
+// digest sizes of the hash functions accepted in multihashes, 0 for any size
+var multihashSizes = map[uint64]uint64{
+	hashIdentity:  0,
+	hashSHA2256:   32,
+	0x13:          64, // sha2-512
+	0x16:          32, // sha3-256
+	hashKeccak256: 32,
+	0x1e:          32, // blake3
+	0xb220:        32, // blake2b-256
+}
+
+const (
+	// MaxContentHashLength is the maximum length of a content hash in binary form
+	MaxContentHashLength = 128
+
+	// length of a v3 onion address without the .onion suffix
+	onion3Length = 56
+)
+
+var base32NoPadding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// ContentHash is the content hash of a name in the binary format of EIP-1577: the
+// varint protocol code of its namespace followed by the content identifier, e.g. a CID
+// for IPFS. Its JSON form is the human readable URI such as ipfs://<cid>.
+type ContentHash []byte
+
+// ParseContentHash parses a content hash given either as a URI (ipfs://<cid>,
+// ipns://<cid>, bzz://<hash> or onion3://<address>) or as 0x prefixed hex of the binary form
+func ParseContentHash(s string) (ContentHash, error) {
+	if strings.HasPrefix(s, "0x") {
+		bz, err := hex.DecodeString(s[2:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex content hash %q", s)
+		}
+		c := ContentHash(bz)
+		if err := c.Validate(); err != nil {
+			return nil, err
+		}
+		return c.toCIDv1(), nil
+	}
+	parts := strings.SplitN(s, "://", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return nil, fmt.Errorf("content hash %q must be a URI such as ipfs://<cid> or 0x prefixed hex", s)
+	}
+	var ns uint64
+	var id []byte
+	switch strings.ToLower(parts[0]) {
+	case "ipfs", "ipns":
+		cid, err := ParseCID(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		ns, id = ContentHashIPFS, cid
+		if strings.ToLower(parts[0]) == "ipns" {
+			ns = ContentHashIPNS
+		}
+		if isCIDv0(id) {
+			// EIP-1577 以 CIDv1 存储内容哈希, CIDv0 需转换
+			id = cidV0ToV1(ns, id)
+		}
+	case "bzz":
+		digest, err := hex.DecodeString(strings.TrimPrefix(parts[1], "0x"))
+		if err != nil || len(digest) != 32 {
+			return nil, fmt.Errorf("swarm hash %q must be 32 bytes of hex", parts[1])
+		}
+		// swarm 内容以 CIDv1 (swarm-manifest, keccak-256) 表示
+		id = appendUvarint(appendUvarint(appendUvarint(appendUvarint([]byte{}, 1), codecSwarmManifest), hashKeccak256), 32)
+		ns, id = ContentHashSwarm, append(id, digest...)
+	case "onion3":
+		ns, id = ContentHashOnion3, []byte(strings.TrimSuffix(parts[1], ".onion"))
+	default:
+		return nil, fmt.Errorf("unsupported content hash scheme %q", parts[0])
+	}
+	c := ContentHash(append(appendUvarint([]byte{}, ns), id...))
+	return c, c.Validate()
+}
+
+// Validate checks that the content hash is well formed for its namespace
+func (c ContentHash) Validate() error {
+	if len(c) > MaxContentHashLength {
+		return fmt.Errorf("content hash cannot be longer than %d bytes", MaxContentHashLength)
+	}
+	ns, id, err := readUvarint(c)
+	if err != nil {
+		return fmt.Errorf("invalid content hash namespace: %s", err)
+	}
+	switch ns {
+	case ContentHashIPFS, ContentHashIPNS:
+		return ValidateCID(id)
+	case ContentHashSwarm:
+		if err := ValidateCID(id); err != nil {
+			return err
+		}
+		if _, codec, mh, _ := splitCID(id); codec != codecSwarmManifest || mh[0] != byte(hashKeccak256) {
+			return fmt.Errorf("swarm content hash must be a keccak-256 swarm manifest")
+		}
+	case ContentHashOnion3:
+		if len(id) != onion3Length {
+			return fmt.Errorf("onion3 address must be %d characters", onion3Length)
+		}
+		if _, err := base32NoPadding.DecodeString(strings.ToUpper(string(id))); err != nil {
+			return fmt.Errorf("invalid onion3 address %q", id)
+		}
+	default:
+		return fmt.Errorf("unsupported content hash namespace 0x%x", ns)
+	}
+	return nil
+}
+
+// toCIDv1 - returns the content hash with a CIDv0 of the IPFS or IPNS namespace
+// converted to the equivalent CIDv1
+func (c ContentHash) toCIDv1() ContentHash {
+	ns, id, err := readUvarint(c)
+	if err != nil || (ns != ContentHashIPFS && ns != ContentHashIPNS) || !isCIDv0(id) {
+		return c
+	}
+	return ContentHash(append(appendUvarint([]byte{}, ns), cidV0ToV1(ns, id)...))
+}
+
+// Hex - returns the binary form of the content hash as 0x prefixed hex
+func (c ContentHash) Hex() string {
+	return "0x" + hex.EncodeToString(c)
+}
+
+// implement fmt.Stringer, returning the content hash as a URI. Malformed content hashes
+// are returned as hex.
+func (c ContentHash) String() string {
+	if len(c) == 0 {
+		return ""
+	}
+	if c.Validate() != nil {
+		return c.Hex()
+	}
+	ns, id, _ := readUvarint(c)
+	switch ns {
+	case ContentHashSwarm:
+		return fmt.Sprintf("%s://%s", contentHashSchemes[ns], hex.EncodeToString(id[len(id)-32:]))
+	case ContentHashOnion3:
+		return fmt.Sprintf("%s://%s", contentHashSchemes[ns], id)
+	default:
+		return fmt.Sprintf("%s://%s", contentHashSchemes[ns], FormatCID(id))
+	}
+}
+
+// MarshalJSON encodes the content hash as its URI
+func (c ContentHash) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+// UnmarshalJSON decodes a content hash from its URI or hex form
+func (c *ContentHash) UnmarshalJSON(bz []byte) error {
+	var s string
+	if err := json.Unmarshal(bz, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		*c = nil
+		return nil
+	}
+	parsed, err := ParseContentHash(s)
+	if err != nil {
+		return err
+	}
+	*c = parsed
+	return nil
+}
+
+// ParseCID decodes a CID given as a base58btc CIDv0 (Qm...) or as a multibase encoded
+// CIDv1 in base32 (b...), base58btc (z...), base16 (f...) or base36 (k...)
+func ParseCID(s string) ([]byte, error) {
+	var bz []byte
+	var err error
+	switch {
+	case len(s) == 46 && strings.HasPrefix(s, "Qm"):
+		bz = base58.Decode(s)
+	case len(s) < 2:
+		return nil, fmt.Errorf("invalid CID %q", s)
+	case s[0] == 'b' || s[0] == 'B':
+		bz, err = base32NoPadding.DecodeString(strings.ToUpper(s[1:]))
+	case s[0] == 'z':
+		bz = base58.Decode(s[1:])
+	case s[0] == 'f' || s[0] == 'F':
+		bz, err = hex.DecodeString(s[1:])
+	case s[0] == 'k' || s[0] == 'K':
+		// IPNS 名称通常以 base36 编码
+		n, ok := new(big.Int).SetString(strings.ToLower(s[1:]), 36)
+		if !ok {
+			return nil, fmt.Errorf("invalid CID %q", s)
+		}
+		bz = n.Bytes()
+	default:
+		return nil, fmt.Errorf("unsupported multibase encoding of CID %q", s)
+	}
+	if err != nil || len(bz) == 0 {
+		return nil, fmt.Errorf("invalid CID %q", s)
+	}
+	if err := ValidateCID(bz); err != nil {
+		return nil, err
+	}
+	return bz, nil
+}
+
+// ValidateCID checks that bz is a binary CIDv0, i.e. a sha2-256 multihash, or a CIDv1
+// of a known content codec
+func ValidateCID(bz []byte) error {
+	version, codec, mh, err := splitCID(bz)
+	if err != nil {
+		return err
+	}
+	if version == 1 && !cidCodecs[codec] {
+		return fmt.Errorf("unsupported CID content codec 0x%x", codec)
+	}
+	return ValidateMultihash(mh)
+}
+
+// FormatCID - returns the string form of a binary CID: base58btc for CIDv0 and
+// lower case base32 for CIDv1
+func FormatCID(bz []byte) string {
+	if isCIDv0(bz) {
+		return base58.Encode(bz)
+	}
+	return "b" + strings.ToLower(base32NoPadding.EncodeToString(bz))
+}
+
+// ValidateMultihash checks that bz is a multihash of a known hash function whose
+// digest has the length of that function
+func ValidateMultihash(bz []byte) error {
+	code, rest, err := readUvarint(bz)
+	if err != nil {
+		return fmt.Errorf("invalid multihash: %s", err)
+	}
+	length, digest, err := readUvarint(rest)
+	if err != nil {
+		return fmt.Errorf("invalid multihash: %s", err)
+	}
+	size, ok := multihashSizes[code]
+	if !ok {
+		return fmt.Errorf("unsupported multihash function 0x%x", code)
+	}
+	if uint64(len(digest)) != length || (size != 0 && length != size) {
+		return fmt.Errorf("invalid multihash digest length %d for function 0x%x", len(digest), code)
+	}
+	return nil
+}
+
+// isCIDv0 - returns whether bz is a CIDv0, which is a bare sha2-256 multihash
+func isCIDv0(bz []byte) bool {
+	return len(bz) == 34 && bz[0] == byte(hashSHA2256) && bz[1] == 32
+}
+
+// cidV0ToV1 - returns the CIDv1 of the multihash of a CIDv0: dag-pb content for IPFS and
+// a libp2p-key for IPNS, whose CIDv0 form is the peer ID of the key
+func cidV0ToV1(ns uint64, mh []byte) []byte {
+	codec := codecDagPB
+	if ns == ContentHashIPNS {
+		codec = codecLibp2pKey
+	}
+	return append(appendUvarint(appendUvarint([]byte{}, 1), codec), mh...)
+}
+
+// splitCID - returns the version, content codec and multihash of a binary CID
+func splitCID(bz []byte) (version uint64, codec uint64, mh []byte, err error) {
+	if isCIDv0(bz) {
+		return 0, codecDagPB, bz, nil
+	}
+	version, rest, err := readUvarint(bz)
+	if err != nil || version != 1 {
+		return 0, 0, nil, fmt.Errorf("invalid CID version")
+	}
+	codec, mh, err = readUvarint(rest)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("invalid CID content codec")
+	}
+	if len(mh) == 0 {
+		return 0, 0, nil, fmt.Errorf("CID is missing its multihash")
+	}
+	return version, codec, mh, nil
+}
+
+// readUvarint - reads an unsigned varint from the start of bz, returning it and the remaining bytes
+func readUvarint(bz []byte) (uint64, []byte, error) {
+	v, n := binary.Uvarint(bz)
+	if n <= 0 {
+		return 0, nil, fmt.Errorf("malformed varint")
+	}
+	return v, bz[n:], nil
+}
+
+// appendUvarint - appends v to bz as an unsigned varint
+func appendUvarint(bz []byte, v uint64) []byte {
+	buf := make([]byte, binary.MaxVarintLen64)
+	return append(bz, buf[:binary.PutUvarint(buf, v)]...)
+}
@@ -0,0 +1,88 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseContentHash(t *testing.T) {
+	const (
+		ipfsV1  = "0xe3010170122029f2d17be6139079dc48696d1f582a8530eb9805b561eda517e22a892c7e3f1f"
+		ipnsV1  = "0xe5010172002408011220e4680b2f8c8d21090e6aa327f1bb342ab8e7d9238f1e35831a54d6a8f5c91124"
+		digest  = "d1de9994b4d039f6548d191eb26786769f580809256b4685ef316805265ea162"
+		onion   = "p53lf57qovyuvwsc6xnrppyply3vtqm7l6pcobkmyqsiofyeznfu5uqd"
+		sha256  = "1220" + "29f2d17be6139079dc48696d1f582a8530eb9805b561eda517e22a892c7e3f1f"
+		short   = "121f" + "29f2d17be6139079dc48696d1f582a8530eb9805b561eda517e22a892c7e3f"
+		swarmV1 = "0xe40101fa011b20" + digest
+	)
+	tests := []struct {
+		name string
+		in   string
+		hex  string // 为空表示应解析失败
+		uri  string
+	}{
+		// IPFS: CIDv0 以 CIDv1 存储
+		{"ipfs CIDv0", "ipfs://QmRAQB6YaCyidP37UdDnjFY5vQuiBrcqdyoW1CuDgwxkD4", ipfsV1, "ipfs://bafybeibj6lixxzqtsb45ysdjnupvqkufgdvzqbnvmhw2kf7cfkesy7r7d4"},
+		{"ipfs CIDv1", "ipfs://bafybeibj6lixxzqtsb45ysdjnupvqkufgdvzqbnvmhw2kf7cfkesy7r7d4", ipfsV1, "ipfs://bafybeibj6lixxzqtsb45ysdjnupvqkufgdvzqbnvmhw2kf7cfkesy7r7d4"},
+		{"ipfs CIDv0 hex", "0xe301" + sha256, ipfsV1, "ipfs://bafybeibj6lixxzqtsb45ysdjnupvqkufgdvzqbnvmhw2kf7cfkesy7r7d4"},
+		{"ipfs hex", ipfsV1, ipfsV1, "ipfs://bafybeibj6lixxzqtsb45ysdjnupvqkufgdvzqbnvmhw2kf7cfkesy7r7d4"},
+		{"ipfs short digest", "0xe3010170" + short, "", ""},
+		{"ipfs digest length mismatch", "0xe3010170122029f2", "", ""},
+		{"ipfs unknown codec", "0xe301019901" + sha256, "", ""},
+		{"ipfs unknown hash function", "0xe3010170" + "990120" + strings.Repeat("00", 32), "", ""},
+		{"ipfs CIDv2", "0xe3010270" + sha256, "", ""},
+		{"ipfs bad CID", "ipfs://QmNotACID", "", ""},
+		{"ipfs unknown multibase", "ipfs://x" + strings.Repeat("a", 40), "", ""},
+
+		// IPNS: CIDv0 是 libp2p-key 的 peer ID
+		{"ipns base36", "ipns://k51qzi5uqu5dlvj2baxnqndepeb86cbk3ng7n3i46uzyxzyqj2xjonzllnv0v8", ipnsV1, "ipns://bafzaajaiaejcbzdibmxyzdjbbehgvizh6g5tikvy47mshdy6gwbruvgwvd24seje"},
+		{"ipns CIDv0", "ipns://QmRAQB6YaCyidP37UdDnjFY5vQuiBrcqdyoW1CuDgwxkD4", "0xe5010172" + sha256, "ipns://bafzbeibj6lixxzqtsb45ysdjnupvqkufgdvzqbnvmhw2kf7cfkesy7r7d4"},
+		{"ipns short digest", "0xe5010172" + short, "", ""},
+
+		// Swarm
+		{"swarm", "bzz://" + digest, swarmV1, "bzz://" + digest},
+		{"swarm 0x", "bzz://0x" + digest, swarmV1, "bzz://" + digest},
+		{"swarm hex", swarmV1, swarmV1, "bzz://" + digest},
+		{"swarm short hash", "bzz://" + digest[2:], "", ""},
+		{"swarm not hex", "bzz://" + strings.Repeat("zz", 32), "", ""},
+		{"swarm dag-pb codec", "0xe4010170" + sha256, "", ""},
+		{"swarm sha2-256", "0xe40101fa01" + sha256, "", ""},
+
+		// onion3
+		{"onion3", "onion3://" + onion, "0xbd03" + hexOf(onion), "onion3://" + onion},
+		{"onion3 suffix", "onion3://" + onion + ".onion", "0xbd03" + hexOf(onion), "onion3://" + onion},
+		{"onion3 short", "onion3://" + onion[1:], "", ""},
+		{"onion3 not base32", "onion3://" + onion[1:] + "1", "", ""},
+
+		// 格式错误
+		{"no scheme", "QmRAQB6YaCyidP37UdDnjFY5vQuiBrcqdyoW1CuDgwxkD4", "", ""},
+		{"unknown scheme", "http://example.com", "", ""},
+		{"empty URI", "ipfs://", "", ""},
+		{"bad hex", "0xzz", "", ""},
+		{"unknown namespace", "0xe601" + sha256, "", ""},
+		{"too long", "0xe3010170" + "008001" + strings.Repeat("00", 128), "", ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := ParseContentHash(tc.in)
+			if tc.hex == "" {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.hex, c.Hex())
+			require.Equal(t, tc.uri, c.String())
+
+			// URI 形式可以解析回相同的内容哈希
+			parsed, err := ParseContentHash(c.String())
+			require.NoError(t, err)
+			require.Equal(t, c, parsed)
+		})
+	}
+}
+
+func hexOf(s string) string {
+	return ContentHash(s).Hex()[2:]
+}
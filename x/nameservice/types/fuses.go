@@ -0,0 +1,103 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Fuses are the permission bits of a subdomain. They are burned by the owner of the
+// parent name, or by the owner of the subdomain once FuseCannotReclaim is burned, and
+// can never be cleared again while the subdomain is registered.
+type Fuses uint32
+
+// Fuses that can be burned on a subdomain
+const (
+	// FuseCannotReclaim - the parent owner can no longer reassign the subdomain
+	FuseCannotReclaim Fuses = 1 << iota
+	// FuseCannotSetRecords - the value and records of the subdomain can no longer be changed
+	FuseCannotSetRecords
+	// FuseCannotTransfer - the owner of the subdomain can no longer transfer it
+	FuseCannotTransfer
+	// FuseCannotCreateChildren - no subdomains can be created below the subdomain anymore
+	FuseCannotCreateChildren
+
+	// AllFuses is the set of all fuses
+	AllFuses = FuseCannotReclaim | FuseCannotSetRecords | FuseCannotTransfer | FuseCannotCreateChildren
+)
+
+var fuseNames = map[Fuses]string{
+	FuseCannotReclaim:        "cannot-reclaim",
+	FuseCannotSetRecords:     "cannot-set-records",
+	FuseCannotTransfer:       "cannot-transfer",
+	FuseCannotCreateChildren: "cannot-create-children",
+}
+
+// ParseFuses parses a list of fuse names such as "cannot-reclaim"
+func ParseFuses(names []string) (Fuses, error) {
+	var fuses Fuses
+	for _, name := range names {
+		fuse, ok := fuseByName(strings.ToLower(strings.TrimSpace(name)))
+		if !ok {
+			return 0, fmt.Errorf("unknown fuse %q", name)
+		}
+		fuses |= fuse
+	}
+	return fuses, nil
+}
+
+func fuseByName(name string) (Fuses, bool) {
+	for fuse, n := range fuseNames {
+		if n == name {
+			return fuse, true
+		}
+	}
+	return 0, false
+}
+
+// Has - returns whether all the given fuses are burned
+func (f Fuses) Has(fuses Fuses) bool {
+	return f&fuses == fuses
+}
+
+// IsValid - returns whether only known fuses are set
+func (f Fuses) IsValid() bool {
+	return f&^AllFuses == 0
+}
+
+// Names - returns the names of the burned fuses in sorted order
+func (f Fuses) Names() []string {
+	names := []string{}
+	for fuse, name := range fuseNames {
+		if f.Has(fuse) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// implement fmt.Stringer
+func (f Fuses) String() string {
+	return strings.Join(f.Names(), ",")
+}
+
+// MarshalJSON encodes the fuses as the list of their names
+func (f Fuses) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.Names())
+}
+
+// UnmarshalJSON decodes the fuses from the list of their names
+func (f *Fuses) UnmarshalJSON(bz []byte) error {
+	var names []string
+	if err := json.Unmarshal(bz, &names); err != nil {
+		return err
+	}
+	fuses, err := ParseFuses(names)
+	if err != nil {
+		return err
+	}
+	*f = fuses
+	return nil
+}
@@ -0,0 +1,71 @@
+package types
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// MaxGatewayURLLength is the maximum length of the URL of a gateway
+	MaxGatewayURLLength = 256
+
+	// GatewayNamePlaceholder is replaced by the name being resolved in the URL of a
+	// gateway. URLs without it get the name appended as the "name" query parameter.
+	GatewayNamePlaceholder = "{name}"
+)
+
+// Gateway redirects the resolution of a name to an off-chain service. The gateway
+// answers with a document of records signed by Signer, so that trust in the answer
+// stays anchored on chain.
+type Gateway struct {
+	URL    string         `json:"url"`
+	Signer sdk.AccAddress `json:"signer"`
+}
+
+// NewGateway returns a gateway
+func NewGateway(url string, signer sdk.AccAddress) Gateway {
+	return Gateway{URL: url, Signer: signer}
+}
+
+// Empty - returns whether no gateway is set
+func (g Gateway) Empty() bool {
+	return g.URL == "" && g.Signer.Empty()
+}
+
+// Validate checks that the gateway has an https URL and a signer
+func (g Gateway) Validate() error {
+	if len(g.URL) > MaxGatewayURLLength {
+		return fmt.Errorf("gateway URL cannot be longer than %d bytes", MaxGatewayURLLength)
+	}
+	u, err := url.Parse(strings.Replace(g.URL, GatewayNamePlaceholder, "name", -1))
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("invalid gateway URL %q", g.URL)
+	}
+	if g.Signer.Empty() {
+		return fmt.Errorf("gateway is missing its signer")
+	}
+	return nil
+}
+
+// QueryURL - returns the URL the gateway is queried at for a name
+func (g Gateway) QueryURL(name string) string {
+	if strings.Contains(g.URL, GatewayNamePlaceholder) {
+		return strings.Replace(g.URL, GatewayNamePlaceholder, url.PathEscape(name), -1)
+	}
+	sep := "?"
+	if strings.Contains(g.URL, "?") {
+		sep = "&"
+	}
+	return g.URL + sep + "name=" + url.QueryEscape(name)
+}
+
+// implement fmt.Stringer
+func (g Gateway) String() string {
+	if g.Empty() {
+		return ""
+	}
+	return fmt.Sprintf("%s (signer %s)", g.URL, g.Signer)
+}
@@ -1,5 +1,11 @@
 package types
 
+import (
+	"encoding/binary"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
 const (
 	// module name
 	ModuleName = "nameservice"
@@ -7,3 +13,161 @@ const (
 	// StoreKey to be used when creating the KVStore
 	StoreKey = ModuleName
 )
+
+// 存储中不同类型的数据使用不同的前缀加以区分
+// Key prefixes for the different kinds of data kept in the nameservice store
+var (
+	WhoisKeyPrefix           = []byte{0x01} // name -> Whois
+	ExpiryQueueKeyPrefix     = []byte{0x02} // expiry height | name -> nil
+	AuctionKeyPrefix         = []byte{0x03} // name -> Auction
+	BidKeyPrefix             = []byte{0x04} // name | 0x00 | bidder -> Bid
+	AuctionQueueKeyPrefix    = []byte{0x05} // reveal end height | name -> nil
+	CommitmentKeyPrefix      = []byte{0x06} // committer | hash -> Commitment
+	CommitmentQueueKeyPrefix = []byte{0x07} // commit height | committer | hash -> nil
+	OwnerIndexKeyPrefix      = []byte{0x08} // owner | name -> nil
+	TransferKeyPrefix        = []byte{0x09} // name -> PendingTransfer
+	RecordsKeyPrefix         = []byte{0x0A} // name -> Records
+	PrimaryNameKeyPrefix     = []byte{0x0B} // address -> primary name
+	ChildIndexKeyPrefix      = []byte{0x0C} // parent | 0x00 | child -> nil
+	TLDKeyPrefix             = []byte{0x0D} // tld -> TLD
+	AddressKeyPrefix         = []byte{0x0E} // name | 0x00 | coin type -> address
+	TextKeyPrefix            = []byte{0x0F} // name | 0x00 | key -> value
+	ContentHashKeyPrefix     = []byte{0x10} // name -> ContentHash
+)
+
+// GetWhoisKey - returns the store key of the Whois record for a name
+func GetWhoisKey(name string) []byte {
+	return append(WhoisKeyPrefix, []byte(name)...)
+}
+
+// GetExpiryQueueHeightKey - returns the prefix of all expiry queue entries
+// for names expiring at the given height
+func GetExpiryQueueHeightKey(height int64) []byte {
+	return append(ExpiryQueueKeyPrefix, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// GetExpiryQueueKey - returns the expiry queue key of a name expiring at the given height
+func GetExpiryQueueKey(height int64, name string) []byte {
+	return append(GetExpiryQueueHeightKey(height), []byte(name)...)
+}
+
+// SplitExpiryQueueKey - returns the height and name stored in an expiry queue key
+func SplitExpiryQueueKey(key []byte) (height int64, name string) {
+	heightBz := key[len(ExpiryQueueKeyPrefix) : len(ExpiryQueueKeyPrefix)+8]
+	return int64(binary.BigEndian.Uint64(heightBz)), string(key[len(ExpiryQueueKeyPrefix)+8:])
+}
+
+// GetAuctionKey - returns the store key of the auction for a name
+func GetAuctionKey(name string) []byte {
+	return append(AuctionKeyPrefix, []byte(name)...)
+}
+
+// GetBidsKey - returns the prefix of all bids committed to the auction for a name
+func GetBidsKey(name string) []byte {
+	return append(append(BidKeyPrefix, []byte(name)...), 0x00)
+}
+
+// GetBidKey - returns the store key of a bidder's sealed bid for a name
+func GetBidKey(name string, bidder sdk.AccAddress) []byte {
+	return append(GetBidsKey(name), bidder.Bytes()...)
+}
+
+// GetAuctionQueueHeightKey - returns the prefix of all auction queue entries
+// for auctions whose reveal window ends at the given height
+func GetAuctionQueueHeightKey(height int64) []byte {
+	return append(AuctionQueueKeyPrefix, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// GetAuctionQueueKey - returns the auction queue key of a name whose reveal window ends at the given height
+func GetAuctionQueueKey(height int64, name string) []byte {
+	return append(GetAuctionQueueHeightKey(height), []byte(name)...)
+}
+
+// SplitAuctionQueueKey - returns the height and name stored in an auction queue key
+func SplitAuctionQueueKey(key []byte) (height int64, name string) {
+	heightBz := key[len(AuctionQueueKeyPrefix) : len(AuctionQueueKeyPrefix)+8]
+	return int64(binary.BigEndian.Uint64(heightBz)), string(key[len(AuctionQueueKeyPrefix)+8:])
+}
+
+// GetCommitmentKey - returns the store key of a commitment made by a committer
+func GetCommitmentKey(committer sdk.AccAddress, hash []byte) []byte {
+	return append(append(CommitmentKeyPrefix, committer.Bytes()...), hash...)
+}
+
+// GetCommitmentQueueHeightKey - returns the prefix of all commitment queue entries
+// for commitments made at the given height
+func GetCommitmentQueueHeightKey(height int64) []byte {
+	return append(CommitmentQueueKeyPrefix, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// GetCommitmentQueueKey - returns the commitment queue key of a commitment made at the given height
+func GetCommitmentQueueKey(height int64, committer sdk.AccAddress, hash []byte) []byte {
+	return append(append(GetCommitmentQueueHeightKey(height), committer.Bytes()...), hash...)
+}
+
+// GetOwnerIndexPrefix - returns the prefix of all owner index entries of an owner
+func GetOwnerIndexPrefix(owner sdk.AccAddress) []byte {
+	return append(OwnerIndexKeyPrefix, owner.Bytes()...)
+}
+
+// GetOwnerIndexKey - returns the owner index key of a name owned by an owner
+func GetOwnerIndexKey(owner sdk.AccAddress, name string) []byte {
+	return append(GetOwnerIndexPrefix(owner), []byte(name)...)
+}
+
+// GetTransferKey - returns the store key of the pending transfer of a name
+func GetTransferKey(name string) []byte {
+	return append(TransferKeyPrefix, []byte(name)...)
+}
+
+// GetRecordsKey - returns the store key of the record set of a name
+func GetRecordsKey(name string) []byte {
+	return append(RecordsKeyPrefix, []byte(name)...)
+}
+
+// GetPrimaryNameKey - returns the store key of the primary name of an address
+func GetPrimaryNameKey(addr sdk.AccAddress) []byte {
+	return append(PrimaryNameKeyPrefix, addr.Bytes()...)
+}
+
+// GetChildIndexPrefix - returns the prefix of the child index entries of all subdomains of a parent
+func GetChildIndexPrefix(parent string) []byte {
+	return append(append(ChildIndexKeyPrefix, []byte(parent)...), 0x00)
+}
+
+// GetChildIndexKey - returns the child index key of a subdomain
+func GetChildIndexKey(parent string, child string) []byte {
+	return append(GetChildIndexPrefix(parent), []byte(child)...)
+}
+
+// GetTLDKey - returns the store key of a TLD
+func GetTLDKey(tld string) []byte {
+	return append(TLDKeyPrefix, []byte(tld)...)
+}
+
+// GetAddressesPrefix - returns the prefix of the address records of a name
+func GetAddressesPrefix(name string) []byte {
+	return append(append(AddressKeyPrefix, []byte(name)...), 0x00)
+}
+
+// GetAddressKey - returns the store key of the address of a name for a coin type
+func GetAddressKey(name string, coinType uint32) []byte {
+	bz := make([]byte, 4)
+	binary.BigEndian.PutUint32(bz, coinType)
+	return append(GetAddressesPrefix(name), bz...)
+}
+
+// GetTextsPrefix - returns the prefix of the text records of a name
+func GetTextsPrefix(name string) []byte {
+	return append(append(TextKeyPrefix, []byte(name)...), 0x00)
+}
+
+// GetTextKey - returns the store key of the text record of a name with the given key
+func GetTextKey(name string, key string) []byte {
+	return append(GetTextsPrefix(name), []byte(key)...)
+}
+
+// GetContentHashKey - returns the store key of the content hash of a name
+func GetContentHashKey(name string) []byte {
+	return append(ContentHashKeyPrefix, []byte(name)...)
+}
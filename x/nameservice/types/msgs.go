@@ -2,7 +2,11 @@ package types
 
 //构建允许用户购买域名和设置解析值的Msg
 import (
+	"fmt"
+	"strings"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 )
 
 const RouterKey = ModuleName // this was defined in your key.go file
@@ -75,8 +79,11 @@ func (msg MsgSetName) ValidateBasic() sdk.Error {
 	if msg.Owner.Empty() {
 		return sdk.ErrInvalidAddress(msg.Owner.String())
 	}
-	if len(msg.Name) == 0 || len(msg.Value) == 0 {
-		return sdk.ErrUnknownRequest("Name and/or Value cannot be empty")
+	if err := ValidateName(msg.Name); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+	if len(msg.Value) == 0 {
+		return sdk.ErrUnknownRequest("Value cannot be empty")
 	}
 	return nil
 }
@@ -100,15 +107,18 @@ type MsgBuyName struct {
 	Name  string         `json:"name"`
 	Bid   sdk.Coins      `json:"bid"`
 	Buyer sdk.AccAddress `json:"buyer"`
+	//与之前提交的承诺（MsgCommitName）相匹配的盐值
+	Salt string `json:"salt"`
 }
 
 // 定义购买域名的Msg
 // NewMsgBuyName is the constructor function for MsgBuyName
-func NewMsgBuyName(name string, bid sdk.Coins, buyer sdk.AccAddress) MsgBuyName {
+func NewMsgBuyName(name string, bid sdk.Coins, buyer sdk.AccAddress, salt string) MsgBuyName {
 	return MsgBuyName{
 		Name:  name,
 		Bid:   bid,
 		Buyer: buyer,
+		Salt:  salt,
 	}
 }
 
@@ -123,12 +133,15 @@ func (msg MsgBuyName) ValidateBasic() sdk.Error {
 	if msg.Buyer.Empty() {
 		return sdk.ErrInvalidAddress(msg.Buyer.String())
 	}
-	if len(msg.Name) == 0 {
-		return sdk.ErrUnknownRequest("Name cannot be empty")
+	if err := ValidateName(msg.Name); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
 	}
 	if !msg.Bid.IsAllPositive() {
 		return sdk.ErrInsufficientCoins("Bids must be positive")
 	}
+	if err := ValidateSalt(msg.Salt); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
 	return nil
 }
 
@@ -141,3 +154,872 @@ func (msg MsgBuyName) GetSignBytes() []byte {
 func (msg MsgBuyName) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Buyer}
 }
+
+// MsgRenewName defines the RenewName message
+// 域名所有者支付续期费用以延长域名的到期高度
+type MsgRenewName struct {
+	Name  string         `json:"name"`
+	Owner sdk.AccAddress `json:"owner"`
+}
+
+// NewMsgRenewName is the constructor function for MsgRenewName
+func NewMsgRenewName(name string, owner sdk.AccAddress) MsgRenewName {
+	return MsgRenewName{
+		Name:  name,
+		Owner: owner,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgRenewName) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgRenewName) Type() string { return "renew_name" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgRenewName) ValidateBasic() sdk.Error {
+	if msg.Owner.Empty() {
+		return sdk.ErrInvalidAddress(msg.Owner.String())
+	}
+	if err := ValidateName(msg.Name); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgRenewName) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgRenewName) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgCommitBid defines the CommitBid message
+// 提交密封出价：只公开出价的哈希值，押金会被托管直到拍卖结算
+type MsgCommitBid struct {
+	Name    string         `json:"name"`
+	BidHash []byte         `json:"bid_hash"`
+	Deposit sdk.Coin       `json:"deposit"`
+	Bidder  sdk.AccAddress `json:"bidder"`
+}
+
+// NewMsgCommitBid is the constructor function for MsgCommitBid
+func NewMsgCommitBid(name string, bidHash []byte, deposit sdk.Coin, bidder sdk.AccAddress) MsgCommitBid {
+	return MsgCommitBid{
+		Name:    name,
+		BidHash: bidHash,
+		Deposit: deposit,
+		Bidder:  bidder,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgCommitBid) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgCommitBid) Type() string { return "commit_bid" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgCommitBid) ValidateBasic() sdk.Error {
+	if msg.Bidder.Empty() {
+		return sdk.ErrInvalidAddress(msg.Bidder.String())
+	}
+	if err := ValidateName(msg.Name); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+	if len(msg.BidHash) != tmhash.Size {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("Bid hash must be %d bytes long", tmhash.Size))
+	}
+	if !msg.Deposit.IsPositive() {
+		return sdk.ErrInsufficientCoins("Deposit must be positive")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgCommitBid) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgCommitBid) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Bidder}
+}
+
+// MsgRevealBid defines the RevealBid message
+// 在揭示期内公开出价和盐值，与之前提交的哈希值进行比对
+type MsgRevealBid struct {
+	Name   string         `json:"name"`
+	Bid    sdk.Coin       `json:"bid"`
+	Salt   string         `json:"salt"`
+	Bidder sdk.AccAddress `json:"bidder"`
+}
+
+// NewMsgRevealBid is the constructor function for MsgRevealBid
+func NewMsgRevealBid(name string, bid sdk.Coin, salt string, bidder sdk.AccAddress) MsgRevealBid {
+	return MsgRevealBid{
+		Name:   name,
+		Bid:    bid,
+		Salt:   salt,
+		Bidder: bidder,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgRevealBid) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgRevealBid) Type() string { return "reveal_bid" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgRevealBid) ValidateBasic() sdk.Error {
+	if msg.Bidder.Empty() {
+		return sdk.ErrInvalidAddress(msg.Bidder.String())
+	}
+	if err := ValidateName(msg.Name); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+	if !msg.Bid.IsPositive() {
+		return sdk.ErrInsufficientCoins("Bids must be positive")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgRevealBid) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgRevealBid) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Bidder}
+}
+
+// MsgCommitName defines the CommitName message
+// 提交域名承诺：只公开（域名，购买者，盐值）的哈希值，成熟后才能用 MsgBuyName 购买该域名
+type MsgCommitName struct {
+	Commitment []byte         `json:"commitment"`
+	Committer  sdk.AccAddress `json:"committer"`
+}
+
+// NewMsgCommitName is the constructor function for MsgCommitName
+func NewMsgCommitName(commitment []byte, committer sdk.AccAddress) MsgCommitName {
+	return MsgCommitName{
+		Commitment: commitment,
+		Committer:  committer,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgCommitName) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgCommitName) Type() string { return "commit_name" }
+
+// ValidateBasic runs stateless checks on the message. The message only carries the hash,
+// so the salt is checked with ValidateSalt where the commitment is computed, and again by
+// MsgBuyName when it is revealed.
+func (msg MsgCommitName) ValidateBasic() sdk.Error {
+	if msg.Committer.Empty() {
+		return sdk.ErrInvalidAddress(msg.Committer.String())
+	}
+	if len(msg.Commitment) != tmhash.Size {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("Commitment must be %d bytes long", tmhash.Size))
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgCommitName) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgCommitName) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Committer}
+}
+
+// MsgTransferName defines the TransferName message
+// 域名所有者将域名转让给接收者。RequireAccept 为 true 时转让进入待确认状态，
+// 需要接收者发送 MsgAcceptTransfer 才能完成，以免把域名转给输错的地址
+type MsgTransferName struct {
+	Name          string         `json:"name"`
+	Owner         sdk.AccAddress `json:"owner"`
+	Recipient     sdk.AccAddress `json:"recipient"`
+	RequireAccept bool           `json:"require_accept"`
+}
+
+// NewMsgTransferName is the constructor function for MsgTransferName
+func NewMsgTransferName(name string, owner, recipient sdk.AccAddress, requireAccept bool) MsgTransferName {
+	return MsgTransferName{
+		Name:          name,
+		Owner:         owner,
+		Recipient:     recipient,
+		RequireAccept: requireAccept,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgTransferName) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgTransferName) Type() string { return "transfer_name" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgTransferName) ValidateBasic() sdk.Error {
+	if msg.Owner.Empty() {
+		return sdk.ErrInvalidAddress(msg.Owner.String())
+	}
+	if msg.Recipient.Empty() {
+		return sdk.ErrInvalidAddress(msg.Recipient.String())
+	}
+	if msg.Owner.Equals(msg.Recipient) {
+		return sdk.ErrUnknownRequest("Recipient is already the owner")
+	}
+	if err := ValidateName(msg.Name); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgTransferName) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgTransferName) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgAcceptTransfer defines the AcceptTransfer message
+// 接收者接受一个待确认的域名转让
+type MsgAcceptTransfer struct {
+	Name      string         `json:"name"`
+	Recipient sdk.AccAddress `json:"recipient"`
+}
+
+// NewMsgAcceptTransfer is the constructor function for MsgAcceptTransfer
+func NewMsgAcceptTransfer(name string, recipient sdk.AccAddress) MsgAcceptTransfer {
+	return MsgAcceptTransfer{
+		Name:      name,
+		Recipient: recipient,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgAcceptTransfer) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgAcceptTransfer) Type() string { return "accept_transfer" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgAcceptTransfer) ValidateBasic() sdk.Error {
+	if msg.Recipient.Empty() {
+		return sdk.ErrInvalidAddress(msg.Recipient.String())
+	}
+	if err := ValidateName(msg.Name); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgAcceptTransfer) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgAcceptTransfer) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Recipient}
+}
+
+// MsgCancelTransfer defines the CancelTransfer message
+// 域名所有者取消一个尚未被接受的域名转让
+type MsgCancelTransfer struct {
+	Name  string         `json:"name"`
+	Owner sdk.AccAddress `json:"owner"`
+}
+
+// NewMsgCancelTransfer is the constructor function for MsgCancelTransfer
+func NewMsgCancelTransfer(name string, owner sdk.AccAddress) MsgCancelTransfer {
+	return MsgCancelTransfer{
+		Name:  name,
+		Owner: owner,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgCancelTransfer) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgCancelTransfer) Type() string { return "cancel_transfer" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgCancelTransfer) ValidateBasic() sdk.Error {
+	if msg.Owner.Empty() {
+		return sdk.ErrInvalidAddress(msg.Owner.String())
+	}
+	if err := ValidateName(msg.Name); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgCancelTransfer) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgCancelTransfer) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgSetRecord defines the SetRecord message
+// 域名所有者添加一条类型化的解析记录，类型和值都相同的记录会被更新（例如修改TTL）
+type MsgSetRecord struct {
+	Name   string         `json:"name"`
+	Record Record         `json:"record"`
+	Owner  sdk.AccAddress `json:"owner"`
+}
+
+// NewMsgSetRecord is the constructor function for MsgSetRecord
+func NewMsgSetRecord(name string, record Record, owner sdk.AccAddress) MsgSetRecord {
+	return MsgSetRecord{
+		Name:   name,
+		Record: record,
+		Owner:  owner,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgSetRecord) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgSetRecord) Type() string { return "set_record" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgSetRecord) ValidateBasic() sdk.Error {
+	if msg.Owner.Empty() {
+		return sdk.ErrInvalidAddress(msg.Owner.String())
+	}
+	if err := ValidateName(msg.Name); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+	if err := msg.Record.Validate(); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgSetRecord) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgSetRecord) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgDeleteRecord defines the DeleteRecord message
+// 域名所有者删除一条解析记录。Value 为空时删除该类型的所有记录
+type MsgDeleteRecord struct {
+	Name       string         `json:"name"`
+	RecordType string         `json:"record_type"`
+	Value      string         `json:"value"`
+	Owner      sdk.AccAddress `json:"owner"`
+}
+
+// NewMsgDeleteRecord is the constructor function for MsgDeleteRecord
+func NewMsgDeleteRecord(name string, recordType string, value string, owner sdk.AccAddress) MsgDeleteRecord {
+	return MsgDeleteRecord{
+		Name:       name,
+		RecordType: strings.ToUpper(recordType),
+		Value:      value,
+		Owner:      owner,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgDeleteRecord) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgDeleteRecord) Type() string { return "delete_record" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgDeleteRecord) ValidateBasic() sdk.Error {
+	if msg.Owner.Empty() {
+		return sdk.ErrInvalidAddress(msg.Owner.String())
+	}
+	if err := ValidateName(msg.Name); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+	if !IsValidRecordType(msg.RecordType) {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("Unsupported record type %q", msg.RecordType))
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgDeleteRecord) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgDeleteRecord) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgSetPrimaryName defines the SetPrimaryName message
+// 将地址的主域名（反向解析的结果）设置为该地址拥有的一个域名，Name 为空时清除主域名
+type MsgSetPrimaryName struct {
+	Name  string         `json:"name"`
+	Owner sdk.AccAddress `json:"owner"`
+}
+
+// NewMsgSetPrimaryName is the constructor function for MsgSetPrimaryName
+func NewMsgSetPrimaryName(name string, owner sdk.AccAddress) MsgSetPrimaryName {
+	return MsgSetPrimaryName{
+		Name:  name,
+		Owner: owner,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgSetPrimaryName) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgSetPrimaryName) Type() string { return "set_primary_name" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgSetPrimaryName) ValidateBasic() sdk.Error {
+	if msg.Owner.Empty() {
+		return sdk.ErrInvalidAddress(msg.Owner.String())
+	}
+	// 空域名表示清除主域名
+	if msg.Name != "" {
+		if err := ValidateName(msg.Name); err != nil {
+			return sdk.ErrUnknownRequest(err.Error())
+		}
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgSetPrimaryName) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgSetPrimaryName) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgCreateSubdomain defines the CreateSubdomain message
+// 父域名的所有者为自己或其他地址创建子域名，或重新分配已存在的子域名
+type MsgCreateSubdomain struct {
+	Name    string         `json:"name"`    // 子域名的完整名称，如 api.acme
+	Owner   sdk.AccAddress `json:"owner"`   // 子域名的所有者
+	Creator sdk.AccAddress `json:"creator"` // 父域名的所有者
+}
+
+// NewMsgCreateSubdomain is the constructor function for MsgCreateSubdomain
+func NewMsgCreateSubdomain(name string, owner sdk.AccAddress, creator sdk.AccAddress) MsgCreateSubdomain {
+	return MsgCreateSubdomain{
+		Name:    name,
+		Owner:   owner,
+		Creator: creator,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgCreateSubdomain) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgCreateSubdomain) Type() string { return "create_subdomain" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgCreateSubdomain) ValidateBasic() sdk.Error {
+	if msg.Creator.Empty() {
+		return sdk.ErrInvalidAddress(msg.Creator.String())
+	}
+	if msg.Owner.Empty() {
+		return sdk.ErrInvalidAddress(msg.Owner.String())
+	}
+	if err := ValidateName(msg.Name); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+	if !IsSubdomain(msg.Name) {
+		return sdk.ErrUnknownRequest("Name must be a subdomain of a parent name")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgCreateSubdomain) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgCreateSubdomain) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
+
+// MsgBurnFuses defines the BurnFuses message
+// 父域名的所有者为子域名烧断权限位，已烧断的权限位不能恢复。
+// 烧断 cannot-reclaim 之后，只有子域名的所有者可以继续烧断权限位
+type MsgBurnFuses struct {
+	Name   string         `json:"name"`   // 子域名的完整名称
+	Fuses  Fuses          `json:"fuses"`  // 要烧断的权限位，与已烧断的合并
+	Signer sdk.AccAddress `json:"signer"` // 父域名的所有者，或已烧断 cannot-reclaim 的子域名的所有者
+}
+
+// NewMsgBurnFuses is the constructor function for MsgBurnFuses
+func NewMsgBurnFuses(name string, fuses Fuses, signer sdk.AccAddress) MsgBurnFuses {
+	return MsgBurnFuses{
+		Name:   name,
+		Fuses:  fuses,
+		Signer: signer,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgBurnFuses) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgBurnFuses) Type() string { return "burn_fuses" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgBurnFuses) ValidateBasic() sdk.Error {
+	if msg.Signer.Empty() {
+		return sdk.ErrInvalidAddress(msg.Signer.String())
+	}
+	if err := ValidateName(msg.Name); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+	if !IsSubdomain(msg.Name) {
+		return sdk.ErrUnknownRequest("Fuses can only be burned on subdomains")
+	}
+	if msg.Fuses == 0 || !msg.Fuses.IsValid() {
+		return sdk.ErrUnknownRequest("Fuses must be a non-empty set of known fuses")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgBurnFuses) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgBurnFuses) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Signer}
+}
+
+// MsgSetTLD defines the SetTLD message
+// 更新一个已存在的顶级域名的规则，只有其注册商可以签署。
+// 新的 TLD 只能通过治理提案（CreateTLDProposal）创建
+type MsgSetTLD struct {
+	TLD    TLD            `json:"tld"`
+	Signer sdk.AccAddress `json:"signer"`
+}
+
+// NewMsgSetTLD is the constructor function for MsgSetTLD
+func NewMsgSetTLD(tld TLD, signer sdk.AccAddress) MsgSetTLD {
+	return MsgSetTLD{
+		TLD:    tld,
+		Signer: signer,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgSetTLD) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgSetTLD) Type() string { return "set_tld" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgSetTLD) ValidateBasic() sdk.Error {
+	if msg.Signer.Empty() {
+		return sdk.ErrInvalidAddress(msg.Signer.String())
+	}
+	if err := msg.TLD.Validate(); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgSetTLD) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgSetTLD) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Signer}
+}
+
+// MsgSetAlias defines the SetAlias message
+// 将域名设为另一个域名的别名，Alias 为空时取消别名
+type MsgSetAlias struct {
+	Name  string         `json:"name"`
+	Alias string         `json:"alias"` // 别名指向的域名
+	Owner sdk.AccAddress `json:"owner"`
+}
+
+// NewMsgSetAlias is the constructor function for MsgSetAlias
+func NewMsgSetAlias(name string, alias string, owner sdk.AccAddress) MsgSetAlias {
+	return MsgSetAlias{
+		Name:  name,
+		Alias: alias,
+		Owner: owner,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgSetAlias) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgSetAlias) Type() string { return "set_alias" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgSetAlias) ValidateBasic() sdk.Error {
+	if msg.Owner.Empty() {
+		return sdk.ErrInvalidAddress(msg.Owner.String())
+	}
+	if err := ValidateName(msg.Name); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+	if msg.Alias == "" {
+		return nil
+	}
+	if err := ValidateName(msg.Alias); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+	if msg.Alias == msg.Name {
+		return sdk.ErrUnknownRequest("Name cannot be an alias of itself")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgSetAlias) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgSetAlias) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgSetAddress defines the SetAddress message
+// 设置域名在某条链（SLIP-44 币种）上的地址，Address 为空时删除该地址
+type MsgSetAddress struct {
+	Name     string         `json:"name"`
+	CoinType uint32         `json:"coin_type"`
+	Address  string         `json:"address"`
+	Owner    sdk.AccAddress `json:"owner"`
+}
+
+// NewMsgSetAddress is the constructor function for MsgSetAddress
+func NewMsgSetAddress(name string, coinType uint32, address string, owner sdk.AccAddress) MsgSetAddress {
+	return MsgSetAddress{
+		Name:     name,
+		CoinType: coinType,
+		Address:  address,
+		Owner:    owner,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgSetAddress) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgSetAddress) Type() string { return "set_address" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgSetAddress) ValidateBasic() sdk.Error {
+	if msg.Owner.Empty() {
+		return sdk.ErrInvalidAddress(msg.Owner.String())
+	}
+	if err := ValidateName(msg.Name); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+	if msg.Address == "" {
+		return nil
+	}
+	if err := ValidateAddress(msg.CoinType, msg.Address); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgSetAddress) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgSetAddress) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgSetText defines the SetText message
+// 设置域名的一条文本记录，Value 为空时删除该记录
+type MsgSetText struct {
+	Name  string         `json:"name"`
+	Key   string         `json:"key"`
+	Value string         `json:"value"`
+	Owner sdk.AccAddress `json:"owner"`
+}
+
+// NewMsgSetText is the constructor function for MsgSetText
+func NewMsgSetText(name string, key string, value string, owner sdk.AccAddress) MsgSetText {
+	return MsgSetText{
+		Name:  name,
+		Key:   key,
+		Value: value,
+		Owner: owner,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgSetText) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgSetText) Type() string { return "set_text" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgSetText) ValidateBasic() sdk.Error {
+	if msg.Owner.Empty() {
+		return sdk.ErrInvalidAddress(msg.Owner.String())
+	}
+	if err := ValidateName(msg.Name); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+	if err := ValidateTextKey(msg.Key); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+	if len(msg.Value) > MaxTextValueLength {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("Text cannot be longer than %d bytes", MaxTextValueLength))
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgSetText) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgSetText) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgSetContentHash defines the SetContentHash message
+// 设置域名的内容哈希，ContentHash 为空时删除
+type MsgSetContentHash struct {
+	Name        string         `json:"name"`
+	ContentHash ContentHash    `json:"content_hash"`
+	Owner       sdk.AccAddress `json:"owner"`
+}
+
+// NewMsgSetContentHash is the constructor function for MsgSetContentHash
+func NewMsgSetContentHash(name string, contentHash ContentHash, owner sdk.AccAddress) MsgSetContentHash {
+	return MsgSetContentHash{
+		Name:        name,
+		ContentHash: contentHash,
+		Owner:       owner,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgSetContentHash) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgSetContentHash) Type() string { return "set_content_hash" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgSetContentHash) ValidateBasic() sdk.Error {
+	if msg.Owner.Empty() {
+		return sdk.ErrInvalidAddress(msg.Owner.String())
+	}
+	if err := ValidateName(msg.Name); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+	if len(msg.ContentHash) == 0 {
+		return nil
+	}
+	if err := msg.ContentHash.Validate(); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgSetContentHash) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgSetContentHash) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgSetGateway defines the SetGateway message
+// 将域名的解析交给链下网关，URL 为空时取消网关
+type MsgSetGateway struct {
+	Name   string         `json:"name"`
+	URL    string         `json:"url"`
+	Signer sdk.AccAddress `json:"signer"` // 网关用于签名应答的账户
+	Owner  sdk.AccAddress `json:"owner"`
+}
+
+// NewMsgSetGateway is the constructor function for MsgSetGateway
+func NewMsgSetGateway(name string, url string, signer sdk.AccAddress, owner sdk.AccAddress) MsgSetGateway {
+	return MsgSetGateway{
+		Name:   name,
+		URL:    url,
+		Signer: signer,
+		Owner:  owner,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgSetGateway) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgSetGateway) Type() string { return "set_gateway" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgSetGateway) ValidateBasic() sdk.Error {
+	if msg.Owner.Empty() {
+		return sdk.ErrInvalidAddress(msg.Owner.String())
+	}
+	if err := ValidateName(msg.Name); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+	gateway := NewGateway(msg.URL, msg.Signer)
+	if gateway.Empty() {
+		return nil
+	}
+	if err := gateway.Validate(); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgSetGateway) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgSetGateway) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
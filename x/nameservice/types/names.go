@@ -0,0 +1,137 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/idna"
+)
+
+// NameSeparator separates the labels of a hierarchical name: "api.acme" is a subdomain of "acme"
+const NameSeparator = "."
+
+// WildcardLabel is the label of a wildcard subdomain: the records of "*.acme" answer
+// for all subdomains of "acme" that do not exist themselves
+const WildcardLabel = "*"
+
+// nameProfile maps names as in UTS #46 for lookup: it folds case, maps compatibility
+// and full-width characters, normalizes to NFC and converts non-ASCII labels to punycode
+var nameProfile = idna.New(idna.MapForLookup(), idna.BidiRule())
+
+// NormalizeName - returns the canonical form of a name, which is the one names are
+// stored, looked up and signed with: "Alice", "ALICE" and "ａｌｉｃｅ" all become "alice"
+// and "bücher" becomes "xn--bcher-kva". Names with control or whitespace characters,
+// or that are not valid under UTS #46, are rejected.
+func NormalizeName(name string) (string, error) {
+	for _, r := range name {
+		if unicode.IsControl(r) || unicode.IsSpace(r) {
+			return "", fmt.Errorf("name %q cannot contain control or whitespace characters", name)
+		}
+	}
+	if err := validateLabels(name); err != nil {
+		return "", err
+	}
+	// 通配符不是合法的 IDNA 字符，只映射其余的标签
+	wildcard := IsWildcard(name)
+	ascii, err := nameProfile.ToASCII(strings.TrimPrefix(name, WildcardLabel+NameSeparator))
+	if err != nil {
+		return "", fmt.Errorf("invalid name %q: %s", name, err)
+	}
+	if wildcard {
+		ascii = SubdomainName(WildcardLabel, ascii)
+	}
+	// 映射可能产生新的分隔符，例如全角句号
+	if err := validateLabels(ascii); err != nil {
+		return "", err
+	}
+	return ascii, nil
+}
+
+// DisplayName - returns the Unicode form of a canonical name for display, e.g. "bücher"
+// for "xn--bcher-kva". Names that cannot be converted are returned unchanged.
+func DisplayName(name string) string {
+	display, err := nameProfile.ToUnicode(name)
+	if err != nil {
+		return name
+	}
+	return display
+}
+
+// NormalizeSearchTerm - maps a search prefix or substring label by label the same way
+// NormalizeName maps names, so that "ＡＬＩＣＥ" finds "alice" and "Bücher" finds
+// "xn--bcher-kva". Search matches the canonical ASCII form of names, so a non-ASCII
+// fragment only matches when it is a whole label: "bü" does not find "bücher".
+// Labels that are not valid on their own, such as "-ice", are only lower-cased.
+func NormalizeSearchTerm(term string) string {
+	labels := strings.Split(term, NameSeparator)
+	for i, label := range labels {
+		if label == "" || label == WildcardLabel {
+			continue
+		}
+		if ascii, err := nameProfile.ToASCII(label); err == nil {
+			labels[i] = ascii
+		} else {
+			labels[i] = strings.ToLower(label)
+		}
+	}
+	return strings.Join(labels, NameSeparator)
+}
+
+// ValidateName checks that a name is valid and already in its canonical form, see NormalizeName
+func ValidateName(name string) error {
+	normalized, err := NormalizeName(name)
+	if err != nil {
+		return err
+	}
+	if normalized != name {
+		return fmt.Errorf("name %q is not in canonical form, use %q", name, normalized)
+	}
+	return nil
+}
+
+// validateLabels checks the dot separated structure of a name: it must not be empty,
+// none of its labels may be empty and a wildcard may only be the leftmost label of a subdomain
+func validateLabels(name string) error {
+	if len(name) == 0 {
+		return fmt.Errorf("name cannot be empty")
+	}
+	labels := strings.Split(name, NameSeparator)
+	for i, label := range labels {
+		if len(label) == 0 {
+			return fmt.Errorf("name %q has an empty label", name)
+		}
+		if strings.Contains(label, WildcardLabel) && (label != WildcardLabel || i != 0 || len(labels) == 1) {
+			return fmt.Errorf("name %q can only have a wildcard as the leftmost label of a subdomain", name)
+		}
+	}
+	return nil
+}
+
+// MaxAliasDepth is the maximum number of aliases followed when resolving a name
+const MaxAliasDepth = 8
+
+// IsWildcard - returns whether a name is a wildcard such as "*.acme"
+func IsWildcard(name string) bool {
+	return strings.HasPrefix(name, WildcardLabel+NameSeparator)
+}
+
+// IsSubdomain - returns whether a name has a parent name
+func IsSubdomain(name string) bool {
+	return strings.Contains(name, NameSeparator)
+}
+
+// ParentName - returns the parent of a subdomain, e.g. "acme" for "api.acme",
+// or false for a top-level name
+func ParentName(name string) (string, bool) {
+	i := strings.Index(name, NameSeparator)
+	if i < 0 {
+		return "", false
+	}
+	return name[i+1:], true
+}
+
+// SubdomainName - returns the name of the subdomain label of parent
+func SubdomainName(label string, parent string) string {
+	return label + NameSeparator + parent
+}
@@ -0,0 +1,205 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params/subspace"
+)
+
+// DefaultParamspace defines the default nameservice module parameter subspace
+const DefaultParamspace = ModuleName
+
+// Default parameter values
+const (
+	DefaultMaxNameLength  uint64 = 64
+	DefaultMaxValueLength uint64 = 256
+	// roughly one year with 5 second blocks
+	DefaultRegistrationPeriod  int64 = 6307200
+	DefaultGracePeriod         int64 = 1555200
+	DefaultAuctionsEnabled           = false // names are bought with MsgBuyName until governance enables auctions
+	DefaultAuctionCommitPeriod int64 = 17280
+	DefaultAuctionRevealPeriod int64 = 17280
+	DefaultCommitRevealEnabled       = true
+	DefaultMinCommitmentAge    int64 = 2
+	DefaultMaxCommitmentAge    int64 = 17280
+)
+
+// Initial Starting Price for a name that was never previously owned
+//如果名称尚未有所有者，我们希望使用 MinPrice 对其进行初始化。
+var DefaultMinNamePrice = sdk.Coins{sdk.NewInt64Coin("nametoken", 1)}
+
+// Fee charged for every renewal of a name
+var DefaultRenewalFee = sdk.Coins{sdk.NewInt64Coin("nametoken", 1)}
+
+// Parameter keys
+var (
+	KeyMinNamePrice        = []byte("MinNamePrice")
+	KeyAllowedBidDenoms    = []byte("AllowedBidDenoms")
+	KeyMaxNameLength       = []byte("MaxNameLength")
+	KeyMaxValueLength      = []byte("MaxValueLength")
+	KeyRegistrationPeriod  = []byte("RegistrationPeriod")
+	KeyGracePeriod         = []byte("GracePeriod")
+	KeyRenewalFee          = []byte("RenewalFee")
+	KeyAuctionsEnabled     = []byte("AuctionsEnabled")
+	KeyAuctionCommitPeriod = []byte("AuctionCommitPeriod")
+	KeyAuctionRevealPeriod = []byte("AuctionRevealPeriod")
+	KeyCommitRevealEnabled = []byte("CommitRevealEnabled")
+	KeyMinCommitmentAge    = []byte("MinCommitmentAge")
+	KeyMaxCommitmentAge    = []byte("MaxCommitmentAge")
+)
+
+var _ subspace.ParamSet = &Params{}
+
+// Params defines the policy of the nameservice module
+type Params struct {
+	MinNamePrice     sdk.Coins `json:"min_name_price"`     // price of a name that has no owner
+	AllowedBidDenoms []string  `json:"allowed_bid_denoms"` // denominations accepted in bids
+	MaxNameLength    uint64    `json:"max_name_length"`
+	MaxValueLength   uint64    `json:"max_value_length"`
+
+	RegistrationPeriod int64     `json:"registration_period"` // blocks a purchase or renewal lasts
+	GracePeriod        int64     `json:"grace_period"`        // blocks after expiry in which only the previous owner may renew
+	RenewalFee         sdk.Coins `json:"renewal_fee"`
+
+	AuctionsEnabled     bool  `json:"auctions_enabled"`      // unowned names can only be won in an auction
+	AuctionCommitPeriod int64 `json:"auction_commit_period"` // blocks, counted from the first bid, in which bids are committed
+	AuctionRevealPeriod int64 `json:"auction_reveal_period"` // blocks after the commit window in which bids are revealed
+
+	CommitRevealEnabled bool  `json:"commit_reveal_enabled"` // MsgBuyName must match a mature commitment
+	MinCommitmentAge    int64 `json:"min_commitment_age"`
+	MaxCommitmentAge    int64 `json:"max_commitment_age"`
+}
+
+// ParamKeyTable for nameservice module
+func ParamKeyTable() subspace.KeyTable {
+	return subspace.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+// ParamSetPairs implements the ParamSet interface and returns all the key/value pairs
+// pairs of nameservice module's parameters.
+// nolint
+func (p *Params) ParamSetPairs() subspace.ParamSetPairs {
+	return subspace.ParamSetPairs{
+		{Key: KeyMinNamePrice, Value: &p.MinNamePrice},
+		{Key: KeyAllowedBidDenoms, Value: &p.AllowedBidDenoms},
+		{Key: KeyMaxNameLength, Value: &p.MaxNameLength},
+		{Key: KeyMaxValueLength, Value: &p.MaxValueLength},
+		{Key: KeyRegistrationPeriod, Value: &p.RegistrationPeriod},
+		{Key: KeyGracePeriod, Value: &p.GracePeriod},
+		{Key: KeyRenewalFee, Value: &p.RenewalFee},
+		{Key: KeyAuctionsEnabled, Value: &p.AuctionsEnabled},
+		{Key: KeyAuctionCommitPeriod, Value: &p.AuctionCommitPeriod},
+		{Key: KeyAuctionRevealPeriod, Value: &p.AuctionRevealPeriod},
+		{Key: KeyCommitRevealEnabled, Value: &p.CommitRevealEnabled},
+		{Key: KeyMinCommitmentAge, Value: &p.MinCommitmentAge},
+		{Key: KeyMaxCommitmentAge, Value: &p.MaxCommitmentAge},
+	}
+}
+
+// DefaultParams returns the default nameservice parameters
+func DefaultParams() Params {
+	return Params{
+		MinNamePrice:        DefaultMinNamePrice,
+		AllowedBidDenoms:    []string{DefaultMinNamePrice[0].Denom},
+		MaxNameLength:       DefaultMaxNameLength,
+		MaxValueLength:      DefaultMaxValueLength,
+		RegistrationPeriod:  DefaultRegistrationPeriod,
+		GracePeriod:         DefaultGracePeriod,
+		RenewalFee:          DefaultRenewalFee,
+		AuctionsEnabled:     DefaultAuctionsEnabled,
+		AuctionCommitPeriod: DefaultAuctionCommitPeriod,
+		AuctionRevealPeriod: DefaultAuctionRevealPeriod,
+		CommitRevealEnabled: DefaultCommitRevealEnabled,
+		MinCommitmentAge:    DefaultMinCommitmentAge,
+		MaxCommitmentAge:    DefaultMaxCommitmentAge,
+	}
+}
+
+// Validate checks that the parameters have valid values
+func (p Params) Validate() error {
+	if !p.MinNamePrice.IsValid() || p.MinNamePrice.Empty() {
+		return fmt.Errorf("min name price must be valid and non-empty: %s", p.MinNamePrice)
+	}
+	if len(p.AllowedBidDenoms) == 0 {
+		return fmt.Errorf("allowed bid denoms cannot be empty")
+	}
+	for _, denom := range p.AllowedBidDenoms {
+		if strings.TrimSpace(denom) == "" {
+			return fmt.Errorf("allowed bid denoms cannot contain an empty denom")
+		}
+	}
+	for _, coin := range p.MinNamePrice {
+		if !p.IsAllowedBidDenom(coin.Denom) {
+			return fmt.Errorf("min name price denom %s is not an allowed bid denom", coin.Denom)
+		}
+	}
+	if p.MaxNameLength == 0 {
+		return fmt.Errorf("max name length must be positive")
+	}
+	if p.MaxValueLength == 0 {
+		return fmt.Errorf("max value length must be positive")
+	}
+	if p.RegistrationPeriod <= 0 {
+		return fmt.Errorf("registration period must be positive: %d", p.RegistrationPeriod)
+	}
+	if p.GracePeriod < 0 {
+		return fmt.Errorf("grace period cannot be negative: %d", p.GracePeriod)
+	}
+	if !p.RenewalFee.IsValid() {
+		return fmt.Errorf("invalid renewal fee: %s", p.RenewalFee)
+	}
+	if p.AuctionCommitPeriod <= 0 || p.AuctionRevealPeriod <= 0 {
+		return fmt.Errorf("auction commit and reveal periods must be positive")
+	}
+	if p.MinCommitmentAge < 0 || p.MaxCommitmentAge < p.MinCommitmentAge {
+		return fmt.Errorf("commitment ages must satisfy 0 <= min (%d) <= max (%d)", p.MinCommitmentAge, p.MaxCommitmentAge)
+	}
+	return nil
+}
+
+// IsAllowedBidDenom returns whether bids may be placed in the given denomination
+func (p Params) IsAllowedBidDenom(denom string) bool {
+	for _, allowed := range p.AllowedBidDenoms {
+		if allowed == denom {
+			return true
+		}
+	}
+	return false
+}
+
+// RootTLD returns the registration policy of top-level names that do not belong to a TLD
+func (p Params) RootTLD() TLD {
+	mode := RegistrationModeOpen
+	if p.AuctionsEnabled {
+		mode = RegistrationModeAuction
+	}
+	return TLD{
+		MinPrice:      p.MinNamePrice,
+		AllowedDenoms: p.AllowedBidDenoms,
+		Mode:          mode,
+	}
+}
+
+// implement fmt.Stringer
+func (p Params) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`Params:
+  Min Name Price:        %s
+  Allowed Bid Denoms:    %s
+  Max Name Length:       %d
+  Max Value Length:      %d
+  Registration Period:   %d
+  Grace Period:          %d
+  Renewal Fee:           %s
+  Auctions Enabled:      %t
+  Auction Commit Period: %d
+  Auction Reveal Period: %d
+  Commit Reveal Enabled: %t
+  Min Commitment Age:    %d
+  Max Commitment Age:    %d`,
+		p.MinNamePrice, strings.Join(p.AllowedBidDenoms, ","), p.MaxNameLength, p.MaxValueLength,
+		p.RegistrationPeriod, p.GracePeriod, p.RenewalFee,
+		p.AuctionsEnabled, p.AuctionCommitPeriod, p.AuctionRevealPeriod,
+		p.CommitRevealEnabled, p.MinCommitmentAge, p.MaxCommitmentAge))
+}
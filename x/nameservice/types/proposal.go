@@ -0,0 +1,73 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+// DefaultCodespace is the codespace of errors returned by nameservice proposals
+const DefaultCodespace sdk.CodespaceType = ModuleName
+
+// ProposalTypeCreateTLD defines the type of a CreateTLDProposal
+const ProposalTypeCreateTLD = "CreateTLD"
+
+func init() {
+	govtypes.RegisterProposalType(ProposalTypeCreateTLD)
+	govtypes.RegisterProposalTypeCodec(CreateTLDProposal{}, "nameservice/CreateTLDProposal")
+}
+
+var _ govtypes.Content = CreateTLDProposal{}
+
+// CreateTLDProposal is a governance proposal that creates a new TLD.
+// 新的顶级域名只能由治理投票通过后创建，之后由其注册商通过 MsgSetTLD 维护
+type CreateTLDProposal struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	TLD         TLD    `json:"tld"`
+}
+
+// NewCreateTLDProposal creates a new CreateTLDProposal
+func NewCreateTLDProposal(title, description string, tld TLD) CreateTLDProposal {
+	return CreateTLDProposal{
+		Title:       title,
+		Description: description,
+		TLD:         tld,
+	}
+}
+
+// GetTitle returns the title of the proposal
+func (p CreateTLDProposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the description of the proposal
+func (p CreateTLDProposal) GetDescription() string { return p.Description }
+
+// ProposalRoute returns the routing key of the proposal
+func (p CreateTLDProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType returns the type of the proposal
+func (p CreateTLDProposal) ProposalType() string { return ProposalTypeCreateTLD }
+
+// ValidateBasic runs stateless checks on the proposal
+func (p CreateTLDProposal) ValidateBasic() sdk.Error {
+	if err := govtypes.ValidateAbstract(DefaultCodespace, p); err != nil {
+		return err
+	}
+	if err := p.TLD.Validate(); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+	return nil
+}
+
+// implement fmt.Stringer
+func (p CreateTLDProposal) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`Create TLD Proposal:
+  Title:       %s
+  Description: %s
+  TLD:         %s
+  Registrar:   %s
+  Min Price:   %s
+  Mode:        %s`, p.Title, p.Description, p.TLD.Name, p.TLD.Registrar, p.TLD.MinPrice, p.TLD.Mode))
+}
@@ -1,15 +1,123 @@
 package types
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
 
 // Query Result Payload for a resolve query
 type QueryResResolve struct {
-	Value string `json:"value"`
+	Value    string   `json:"value"`
+	Wildcard string   `json:"wildcard,omitempty"` // wildcard name such as *.acme that answered, empty for an exact match
+	Chain    []string `json:"chain,omitempty"`    // names followed through aliases, starting at the resolved name
+	Gateway  *Gateway `json:"gateway,omitempty"`  // set when the name is resolved off chain by a gateway
 }
 
 // implement fmt.Stringer
 func (r QueryResResolve) String() string {
-	return r.Value
+	var notes []string
+	if r.Wildcard != "" {
+		notes = append(notes, "wildcard "+r.Wildcard)
+	}
+	if len(r.Chain) > 1 {
+		notes = append(notes, "via "+strings.Join(r.Chain, " -> "))
+	}
+	if r.Gateway != nil {
+		notes = append(notes, "resolved off chain by "+r.Gateway.String())
+	}
+	if len(notes) == 0 {
+		return r.Value
+	}
+	return fmt.Sprintf("%s (%s)", r.Value, strings.Join(notes, ", "))
+}
+
+// Query Result Payload for a records query
+type QueryResRecords struct {
+	Name     string  `json:"name"`
+	Wildcard string  `json:"wildcard,omitempty"` // wildcard name such as *.acme that answered, empty for an exact match
+	Records  Records `json:"records"`
+}
+
+// implement fmt.Stringer
+func (r QueryResRecords) String() string {
+	if r.Wildcard != "" {
+		return fmt.Sprintf("; answered by wildcard %s\n%s", r.Wildcard, r.Records)
+	}
+	return r.Records.String()
+}
+
+// Query Result Payload for an addr query
+type QueryResAddresses struct {
+	Name      string         `json:"name"`
+	Wildcard  string         `json:"wildcard,omitempty"` // wildcard name that answered, empty for an exact match
+	Addresses AddressRecords `json:"addresses"`
+}
+
+// implement fmt.Stringer
+func (r QueryResAddresses) String() string {
+	if r.Wildcard != "" {
+		return fmt.Sprintf("; answered by wildcard %s\n%s", r.Wildcard, r.Addresses)
+	}
+	return r.Addresses.String()
+}
+
+// Query Result Payload for a text query
+type QueryResTexts struct {
+	Name     string      `json:"name"`
+	Wildcard string      `json:"wildcard,omitempty"` // wildcard name that answered, empty for an exact match
+	Texts    TextRecords `json:"texts"`
+}
+
+// implement fmt.Stringer
+func (r QueryResTexts) String() string {
+	if r.Wildcard != "" {
+		return fmt.Sprintf("; answered by wildcard %s\n%s", r.Wildcard, r.Texts)
+	}
+	return r.Texts.String()
+}
+
+// Query Result Payload for a contenthash query, giving the content hash both in
+// binary form as hex and as a URI
+type QueryResContentHash struct {
+	Name     string `json:"name"`
+	Wildcard string `json:"wildcard,omitempty"` // wildcard name that answered, empty for an exact match
+	Raw      string `json:"raw"`
+	URI      string `json:"uri"`
+}
+
+// NewQueryResContentHash - returns the query result of the content hash of a name
+func NewQueryResContentHash(name string, contentHash ContentHash) QueryResContentHash {
+	return QueryResContentHash{Name: name, Raw: contentHash.Hex(), URI: contentHash.String()}
+}
+
+// implement fmt.Stringer
+func (r QueryResContentHash) String() string {
+	return fmt.Sprintf("%s\n%s", r.URI, r.Raw)
+}
+
+// Query Result Payload for a reverse query
+type QueryResReverse struct {
+	Address sdk.AccAddress `json:"address"`
+	Name    string         `json:"name"`
+}
+
+// implement fmt.Stringer
+func (r QueryResReverse) String() string {
+	return r.Name
+}
+
+// Query Result Payload for a tlds query
+type QueryResTLDs []TLD
+
+// implement fmt.Stringer
+func (t QueryResTLDs) String() string {
+	tlds := make([]string, len(t))
+	for i, tld := range t {
+		tlds[i] = tld.String()
+	}
+	return strings.Join(tlds, "\n\n")
 }
 
 // Query Result Payload for a names query
@@ -19,3 +127,167 @@ type QueryResNames []string
 func (n QueryResNames) String() string {
 	return strings.Join(n[:], "\n")
 }
+
+// Default and maximum number of names returned by one page of a names query
+const (
+	DefaultNamesLimit = 100
+	MaxNamesLimit     = 1000
+)
+
+// QueryNamesParams defines the params of a paginated names query. When StartKey is set
+// the page starts at that name (inclusive) and Page is ignored. When Parent is set only
+// the direct subdomains of that name are listed.
+type QueryNamesParams struct {
+	Page     int    `json:"page"`
+	Limit    int    `json:"limit"`
+	StartKey string `json:"start_key"`
+	Parent   string `json:"parent"`
+}
+
+// NewQueryNamesParams creates a new instance of QueryNamesParams
+func NewQueryNamesParams(page, limit int, startKey string, parent string) QueryNamesParams {
+	return QueryNamesParams{
+		Page:     page,
+		Limit:    limit,
+		StartKey: startKey,
+		Parent:   parent,
+	}
+}
+
+// Query Result Payload for a paginated names query.
+// NextKey is the StartKey of the next page, or empty on the last page.
+type QueryResNamesPage struct {
+	Names   QueryResNames `json:"names"`
+	NextKey string        `json:"next_key"`
+}
+
+// implement fmt.Stringer
+func (p QueryResNamesPage) String() string {
+	if p.NextKey == "" {
+		return p.Names.String()
+	}
+	return fmt.Sprintf("%s\nNext Key: %s", p.Names, p.NextKey)
+}
+
+// Query Result Payload for an auction query
+type QueryResAuction struct {
+	Auction Auction `json:"auction"`
+	Phase   string  `json:"phase"`
+}
+
+// implement fmt.Stringer
+func (r QueryResAuction) String() string {
+	return fmt.Sprintf("%s\nPhase: %s", r.Auction, r.Phase)
+}
+
+// Maximum number of candidate names in a single availability query and the number of
+// free alternatives suggested for each name that is already owned
+const (
+	MaxAvailabilityNames = 100
+	MaxAlternatives      = 5
+)
+
+// MaxSearchScan is the maximum number of names a single search scans. A search that
+// stops at this bound returns the NextKey to continue from, even if the page is not full.
+const MaxSearchScan = 1000
+
+// QuerySearchParams defines the params of a names search. Names must start with Prefix
+// and contain Contains; either may be empty but not both. StartKey continues a search
+// from the NextKey of its previous page.
+type QuerySearchParams struct {
+	Prefix   string `json:"prefix"`
+	Contains string `json:"contains"`
+	StartKey string `json:"start_key"`
+	Limit    int    `json:"limit"`
+}
+
+// NewQuerySearchParams creates a new instance of QuerySearchParams
+func NewQuerySearchParams(prefix, contains, startKey string, limit int) QuerySearchParams {
+	return QuerySearchParams{
+		Prefix:   prefix,
+		Contains: contains,
+		StartKey: startKey,
+		Limit:    limit,
+	}
+}
+
+// NameSummary is the short form of a Whois returned by a names search
+type NameSummary struct {
+	Name    string         `json:"name"`
+	Owner   sdk.AccAddress `json:"owner"`
+	Price   sdk.Coins      `json:"price"`
+	Expires int64          `json:"expires"`
+}
+
+// implement fmt.Stringer
+func (s NameSummary) String() string {
+	return fmt.Sprintf("%s\towner=%s\tprice=%s\texpires=%d", s.Name, s.Owner, s.Price, s.Expires)
+}
+
+// Query Result Payload for a names search
+type QueryResSearch []NameSummary
+
+// implement fmt.Stringer
+func (r QueryResSearch) String() string {
+	lines := make([]string, len(r))
+	for i, s := range r {
+		lines[i] = s.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Query Result Payload for one page of a names search.
+// NextKey is the StartKey of the next page, or empty once all names were scanned.
+type QueryResSearchPage struct {
+	Results QueryResSearch `json:"results"`
+	NextKey string         `json:"next_key"`
+}
+
+// implement fmt.Stringer
+func (p QueryResSearchPage) String() string {
+	if p.NextKey == "" {
+		return p.Results.String()
+	}
+	return fmt.Sprintf("%s\nNext Key: %s", p.Results, p.NextKey)
+}
+
+// QueryAvailabilityParams defines the params of a bulk availability query
+type QueryAvailabilityParams struct {
+	Names []string `json:"names"`
+}
+
+// NewQueryAvailabilityParams creates a new instance of QueryAvailabilityParams
+func NewQueryAvailabilityParams(names []string) QueryAvailabilityParams {
+	return QueryAvailabilityParams{Names: names}
+}
+
+// NameAvailability reports whether a name is owned and what it currently costs.
+// Alternatives lists similar names that are still free when the name is owned.
+type NameAvailability struct {
+	Name         string         `json:"name"`
+	Owned        bool           `json:"owned"`
+	Owner        sdk.AccAddress `json:"owner"`
+	Price        sdk.Coins      `json:"price"`
+	Alternatives []string       `json:"alternatives"`
+}
+
+// implement fmt.Stringer
+func (a NameAvailability) String() string {
+	if !a.Owned {
+		return fmt.Sprintf("%s\tavailable\tprice=%s", a.Name, a.Price)
+	}
+	return fmt.Sprintf("%s\towned by %s\tprice=%s\talternatives=%s",
+		a.Name, a.Owner, a.Price, strings.Join(a.Alternatives, ","))
+}
+
+// Query Result Payload for a bulk availability query
+type QueryResAvailability []NameAvailability
+
+// implement fmt.Stringer
+func (r QueryResAvailability) String() string {
+	lines := make([]string, len(r))
+	for i, a := range r {
+		lines[i] = a.String()
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,175 @@
+package types
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Record types that can be published for a name
+const (
+	RecordTypeA     = "A"
+	RecordTypeAAAA  = "AAAA"
+	RecordTypeCNAME = "CNAME"
+	RecordTypeMX    = "MX"
+	RecordTypeTXT   = "TXT"
+	RecordTypeSRV   = "SRV"
+)
+
+const (
+	// DefaultRecordTTL is the TTL, in seconds, of records set without one
+	DefaultRecordTTL uint32 = 3600
+
+	// MaxRecordsPerName is the maximum number of records a single name can hold
+	MaxRecordsPerName = 32
+)
+
+// Record is a typed DNS-style record of a name. Value holds the record data in
+// zone file presentation format:
+//
+//	A      192.0.2.1
+//	AAAA   2001:db8::1
+//	CNAME  target.example.
+//	MX     <preference> <exchange>
+//	TXT    free-form text
+//	SRV    <priority> <weight> <port> <target>
+type Record struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+	TTL   uint32 `json:"ttl"`
+}
+
+// NewRecord returns a record, using the default TTL when ttl is zero
+func NewRecord(recordType string, value string, ttl uint32) Record {
+	if ttl == 0 {
+		ttl = DefaultRecordTTL
+	}
+	return Record{
+		Type:  strings.ToUpper(recordType),
+		Value: value,
+		TTL:   ttl,
+	}
+}
+
+// IsValidRecordType - returns whether records of the given type can be published
+func IsValidRecordType(recordType string) bool {
+	switch recordType {
+	case RecordTypeA, RecordTypeAAAA, RecordTypeCNAME, RecordTypeMX, RecordTypeTXT, RecordTypeSRV:
+		return true
+	default:
+		return false
+	}
+}
+
+// Validate checks that the value of the record is well formed for its type
+func (r Record) Validate() error {
+	switch r.Type {
+	case RecordTypeA:
+		ip := net.ParseIP(r.Value)
+		if ip == nil || ip.To4() == nil || strings.Contains(r.Value, ":") {
+			return fmt.Errorf("invalid IPv4 address %q", r.Value)
+		}
+	case RecordTypeAAAA:
+		ip := net.ParseIP(r.Value)
+		if ip == nil || !strings.Contains(r.Value, ":") {
+			return fmt.Errorf("invalid IPv6 address %q", r.Value)
+		}
+	case RecordTypeCNAME:
+		if !IsValidHostname(r.Value) {
+			return fmt.Errorf("invalid CNAME target %q", r.Value)
+		}
+	case RecordTypeMX:
+		fields := strings.Fields(r.Value)
+		if len(fields) != 2 {
+			return fmt.Errorf("MX record must be \"<preference> <exchange>\", got %q", r.Value)
+		}
+		if _, err := strconv.ParseUint(fields[0], 10, 16); err != nil {
+			return fmt.Errorf("invalid MX preference %q", fields[0])
+		}
+		if !IsValidHostname(fields[1]) {
+			return fmt.Errorf("invalid MX exchange %q", fields[1])
+		}
+	case RecordTypeTXT:
+		if len(r.Value) == 0 {
+			return fmt.Errorf("TXT record cannot be empty")
+		}
+	case RecordTypeSRV:
+		fields := strings.Fields(r.Value)
+		if len(fields) != 4 {
+			return fmt.Errorf("SRV record must be \"<priority> <weight> <port> <target>\", got %q", r.Value)
+		}
+		for _, field := range fields[:3] {
+			if _, err := strconv.ParseUint(field, 10, 16); err != nil {
+				return fmt.Errorf("invalid SRV priority, weight or port %q", field)
+			}
+		}
+		if fields[3] != "." && !IsValidHostname(fields[3]) {
+			return fmt.Errorf("invalid SRV target %q", fields[3])
+		}
+	default:
+		return fmt.Errorf("unsupported record type %q", r.Type)
+	}
+	return nil
+}
+
+// implement fmt.Stringer
+func (r Record) String() string {
+	return fmt.Sprintf("%s\t%d\t%s", r.Type, r.TTL, r.Value)
+}
+
+// IsValidHostname - returns whether s is a DNS hostname: dot separated labels of
+// letters, digits, hyphens and underscores, optionally fully qualified with a trailing dot
+func IsValidHostname(s string) bool {
+	s = strings.TrimSuffix(s, ".")
+	if len(s) == 0 || len(s) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(s, ".") {
+		if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Records is the record set of a name
+type Records []Record
+
+// Filter - returns the records of the given type, or all records if recordType is empty
+func (rs Records) Filter(recordType string) Records {
+	if recordType == "" {
+		return rs
+	}
+	filtered := Records{}
+	for _, r := range rs {
+		if r.Type == recordType {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
+
+// Index - returns the position of the record with the given type and value, or -1
+func (rs Records) Index(recordType string, value string) int {
+	for i, r := range rs {
+		if r.Type == recordType && r.Value == value {
+			return i
+		}
+	}
+	return -1
+}
+
+// implement fmt.Stringer
+func (rs Records) String() string {
+	lines := make([]string, len(rs))
+	for i, r := range rs {
+		lines[i] = r.String()
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,59 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Tag keys and values emitted by the nameservice handlers.
+// The action tag (the msg Type) is appended by baseapp for every msg.
+var (
+	TxCategory = ModuleName
+
+	TagAction        = sdk.TagAction
+	TagCategory      = sdk.TagCategory
+	TagName          = "name"
+	TagOwner         = "owner"
+	TagPreviousOwner = "previous-owner"
+	TagBuyer         = "buyer"
+	TagPrice         = "price"
+	TagBidder        = "bidder"
+	TagCommitter     = "committer"
+	TagRecipient     = "recipient"
+	TagRecordType    = "record-type"
+	TagParent        = "parent"
+	TagFuses         = "fuses"
+	TagTLD           = "tld"
+	TagRegistrar     = "registrar"
+	TagAlias         = "alias"
+	TagCoinType      = "coin-type"
+	TagTextKey       = "text-key"
+	TagGateway       = "gateway"
+
+	// actions of tags emitted from the EndBlocker
+	ActionAuctionSettled = "auction_settled"
+	ActionNameReleased   = "name_released"
+)
+
+// BuyNameResult is returned in the Data of a successful MsgBuyName
+type BuyNameResult struct {
+	Name          string         `json:"name"`
+	PreviousOwner sdk.AccAddress `json:"previous_owner"` // empty if the name had no owner
+	Price         sdk.Coins      `json:"price"`
+	Expires       int64          `json:"expires"`
+}
+
+// RenewNameResult is returned in the Data of a successful MsgRenewName
+type RenewNameResult struct {
+	Name    string    `json:"name"`
+	Fee     sdk.Coins `json:"fee"`
+	Expires int64     `json:"expires"`
+}
+
+// TransferNameResult is returned in the Data of a successful MsgTransferName.
+// Pending is set when the recipient still has to accept the transfer.
+type TransferNameResult struct {
+	Name      string         `json:"name"`
+	Owner     sdk.AccAddress `json:"owner"`
+	Recipient sdk.AccAddress `json:"recipient"`
+	Pending   bool           `json:"pending"`
+}
@@ -0,0 +1,71 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// MaxTextsPerName is the maximum number of text records a single name can hold
+	MaxTextsPerName = 32
+
+	// MaxTextKeyLength is the maximum length of the key of a text record
+	MaxTextKeyLength = 64
+
+	// MaxTextValueLength is the maximum length of the value of a text record
+	MaxTextValueLength = 1024
+)
+
+// TextRecord is a key/value text record of a name, such as avatar, url, email,
+// description or com.github, which together make up the public profile of the name
+type TextRecord struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// implement fmt.Stringer
+func (t TextRecord) String() string {
+	return fmt.Sprintf("%s\t%s", t.Key, t.Value)
+}
+
+// TextRecords is the list of text records of a name, ordered by key
+type TextRecords []TextRecord
+
+// implement fmt.Stringer
+func (ts TextRecords) String() string {
+	lines := make([]string, len(ts))
+	for i, t := range ts {
+		lines[i] = t.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ValidateTextKey - checks that key is a non-empty key of printable ASCII characters without whitespace
+func ValidateTextKey(key string) error {
+	if len(key) == 0 {
+		return fmt.Errorf("text key cannot be empty")
+	}
+	if len(key) > MaxTextKeyLength {
+		return fmt.Errorf("text key cannot be longer than %d bytes", MaxTextKeyLength)
+	}
+	for _, c := range key {
+		if c <= ' ' || c > '~' {
+			return fmt.Errorf("text key %q contains invalid character %q", key, c)
+		}
+	}
+	return nil
+}
+
+// Validate checks that the key and the value of the text record are well formed
+func (t TextRecord) Validate() error {
+	if err := ValidateTextKey(t.Key); err != nil {
+		return err
+	}
+	if len(t.Value) == 0 {
+		return fmt.Errorf("text %s cannot be empty", t.Key)
+	}
+	if len(t.Value) > MaxTextValueLength {
+		return fmt.Errorf("text %s cannot be longer than %d bytes", t.Key, MaxTextValueLength)
+	}
+	return nil
+}
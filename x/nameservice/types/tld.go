@@ -0,0 +1,115 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Registration modes of a TLD
+const (
+	// RegistrationModeOpen - unowned names are bought with MsgBuyName
+	RegistrationModeOpen = "open"
+	// RegistrationModeAuction - unowned names can only be won in a sealed-bid auction
+	RegistrationModeAuction = "auction"
+	// RegistrationModeRegistrar - only the registrar can register names
+	RegistrationModeRegistrar = "registrar"
+)
+
+// TLD is a top-level namespace such as "dev" with its own registration policy.
+// Names directly below it (e.g. "alice.dev") are registered under that policy and
+// payments for them go to the registrar instead of being burned.
+//
+// Top-level names without a TLD (e.g. "alice") are registered under the root
+// namespace, whose policy is derived from the module parameters.
+type TLD struct {
+	Name          string         `json:"name"`
+	Registrar     sdk.AccAddress `json:"registrar"`
+	MinPrice      sdk.Coins      `json:"min_price"`      // price of a name that has no owner
+	AllowedDenoms []string       `json:"allowed_denoms"` // denominations accepted in bids
+	Mode          string         `json:"mode"`
+}
+
+// NewTLD returns a new TLD
+func NewTLD(name string, registrar sdk.AccAddress, minPrice sdk.Coins, allowedDenoms []string, mode string) TLD {
+	return TLD{
+		Name:          name,
+		Registrar:     registrar,
+		MinPrice:      minPrice,
+		AllowedDenoms: allowedDenoms,
+		Mode:          strings.ToLower(mode),
+	}
+}
+
+// IsValidRegistrationMode - returns whether the mode is a known registration mode
+func IsValidRegistrationMode(mode string) bool {
+	switch mode {
+	case RegistrationModeOpen, RegistrationModeAuction, RegistrationModeRegistrar:
+		return true
+	default:
+		return false
+	}
+}
+
+// Validate checks that the TLD is well formed
+func (t TLD) Validate() error {
+	if err := ValidateName(t.Name); err != nil {
+		return err
+	}
+	if IsSubdomain(t.Name) {
+		return fmt.Errorf("TLD %q must be a single label", t.Name)
+	}
+	if t.Registrar.Empty() {
+		return fmt.Errorf("TLD %q is missing a registrar", t.Name)
+	}
+	if !t.MinPrice.IsValid() || t.MinPrice.Empty() {
+		return fmt.Errorf("min price of TLD %q must be valid and non-empty: %s", t.Name, t.MinPrice)
+	}
+	if len(t.AllowedDenoms) == 0 {
+		return fmt.Errorf("allowed denoms of TLD %q cannot be empty", t.Name)
+	}
+	for _, coin := range t.MinPrice {
+		if !t.IsAllowedBidDenom(coin.Denom) {
+			return fmt.Errorf("min price denom %s of TLD %q is not an allowed denom", coin.Denom, t.Name)
+		}
+	}
+	if !IsValidRegistrationMode(t.Mode) {
+		return fmt.Errorf("unknown registration mode %q", t.Mode)
+	}
+	return nil
+}
+
+// IsRoot - returns whether this is the root namespace derived from the module parameters
+func (t TLD) IsRoot() bool {
+	return t.Name == ""
+}
+
+// IsAllowedBidDenom returns whether bids may be placed in the given denomination
+func (t TLD) IsAllowedBidDenom(denom string) bool {
+	for _, allowed := range t.AllowedDenoms {
+		if allowed == denom {
+			return true
+		}
+	}
+	return false
+}
+
+// AuctionDenom returns the only denomination accepted for auction bids
+func (t TLD) AuctionDenom() string {
+	return t.MinPrice[0].Denom
+}
+
+// AuctionMinPrice returns the lowest bid accepted in an auction
+func (t TLD) AuctionMinPrice() sdk.Coin {
+	return sdk.NewCoin(t.AuctionDenom(), t.MinPrice.AmountOf(t.AuctionDenom()))
+}
+
+// implement fmt.Stringer
+func (t TLD) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`Name: %s
+Registrar: %s
+Min Price: %s
+Allowed Denoms: %s
+Mode: %s`, t.Name, t.Registrar, t.MinPrice, strings.Join(t.AllowedDenoms, ","), t.Mode))
+}
@@ -0,0 +1,25 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// PendingTransfer is a transfer of a name that waits for the recipient to accept it.
+// It is dropped as soon as the name changes owner in any other way.
+type PendingTransfer struct {
+	Name      string         `json:"name"`
+	Owner     sdk.AccAddress `json:"owner"`
+	Recipient sdk.AccAddress `json:"recipient"`
+	Height    int64          `json:"height"` // height at which the transfer was started
+}
+
+// implement fmt.Stringer
+func (t PendingTransfer) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`Name: %s
+Owner: %s
+Recipient: %s
+Height: %d`, t.Name, t.Owner, t.Recipient, t.Height))
+}
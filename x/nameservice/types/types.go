@@ -10,33 +10,53 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
-
 // Whois is a struct that contains all the metadata of a name
 type Whois struct {
 	//域名解析出的值。这是任意字符串，
 	//但将来您可以修改它以要求它适合特定格式，
 	//例如IP地址，DNS区域文件或区块链地址。
-	Value string         `json:"value"`
+	Value string `json:"value"`
 	//该域名当前所有者的地址
 	Owner sdk.AccAddress `json:"owner"`
 	//你需要为购买域名支付的费用
-	Price sdk.Coins      `json:"price"`
+	Price sdk.Coins `json:"price"`
+	//域名到期的区块高度，到期后进入宽限期
+	Expires int64 `json:"expires"`
+	//父域名的所有者为子域名烧断的权限位，一旦烧断便不能恢复
+	Fuses Fuses `json:"fuses"`
+	//域名是另一个域名的别名时，解析会继续跟随到该域名
+	Alias string `json:"alias"`
+	//域名在链下解析时，链下网关的地址及其签名者
+	Gateway Gateway `json:"gateway"`
 }
 
-// Initial Starting Price for a name that was never previously owned
-//如果名称尚未有所有者，我们希望使用 MinPrice 对其进行初始化。
-var MinNamePrice = sdk.Coins{sdk.NewInt64Coin("nametoken", 1)}
-
 // Returns a new Whois with the minprice as the price
-func NewWhois() Whois {
+//如果名称尚未有所有者，我们希望使用 MinPrice 对其进行初始化。
+func NewWhois(minPrice sdk.Coins) Whois {
 	return Whois{
-		Price: MinNamePrice,
+		Price: minPrice,
 	}
 }
 
+// IsExpired - returns whether the registration has run out at the given height
+func (w Whois) IsExpired(height int64) bool {
+	return !w.Owner.Empty() && height > w.Expires
+}
+
+// InGracePeriod - returns whether the name is expired but may still be renewed
+// by its previous owner
+//宽限期内只有之前的所有者可以续期，宽限期结束后域名被释放
+func (w Whois) InGracePeriod(height int64, gracePeriod int64) bool {
+	return w.IsExpired(height) && height <= w.Expires+gracePeriod
+}
+
 // implement fmt.Stringer
 func (w Whois) String() string {
 	return strings.TrimSpace(fmt.Sprintf(`Owner: %s
 Value: %s
-Price: %s`, w.Owner, w.Value, w.Price))
+Price: %s
+Expires: %d
+Fuses: %s
+Alias: %s
+Gateway: %s`, w.Owner, w.Value, w.Price, w.Expires, w.Fuses, w.Alias, w.Gateway))
 }